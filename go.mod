@@ -3,13 +3,22 @@ module github.com/Now-AI-Foundry/Now-SC
 go 1.24.5
 
 require (
+	github.com/fatih/color v1.18.0
+	github.com/manifoldco/promptui v0.9.0
+	github.com/spf13/cobra v1.10.1
+	github.com/zalando/go-keyring v0.2.6
+	golang.org/x/term v0.24.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	al.essio.dev/pkg/shellescape v1.5.1 // indirect
 	github.com/chzyer/readline v0.0.0-20180603132655-2972be24d48e // indirect
-	github.com/fatih/color v1.18.0 // indirect
+	github.com/danieljoos/wincred v1.2.2 // indirect
+	github.com/godbus/dbus/v5 v5.1.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/manifoldco/promptui v0.9.0 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	github.com/spf13/cobra v1.10.1 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
-	golang.org/x/sys v0.25.0 // indirect
+	golang.org/x/sys v0.26.0 // indirect
 )
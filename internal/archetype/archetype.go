@@ -0,0 +1,317 @@
+package archetype
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/git"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// DefinitionFile describes an archetype; FilesDir holds the files it seeds
+const (
+	DefinitionFile = "archetype.yaml"
+	FilesDir       = "files"
+)
+
+// Workflow is a recommended sequence of now-sc commands for the engagement
+type Workflow struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Steps       []string `yaml:"steps"`
+}
+
+// Archetype is a project scaffold for a kind of engagement
+type Archetype struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description,omitempty"`
+	Folders     []project.Folder  `yaml:"folders,omitempty"`
+	Prompts     []string          `yaml:"prompts,omitempty"`
+	Workflows   []Workflow        `yaml:"workflows,omitempty"`
+	Variables   map[string]string `yaml:"variables,omitempty"`
+
+	Dir string `yaml:"-"` // Directory the archetype was loaded from
+}
+
+// SearchPaths returns the local directories archetypes are looked up in:
+// the org directory first, then the user's config directory
+func SearchPaths() []string {
+	var paths []string
+	if orgDir := os.Getenv("NOW_SC_ORG_DIR"); orgDir != "" {
+		paths = append(paths, filepath.Join(orgDir, "archetypes"))
+	}
+	if configDir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(configDir, "now-sc", "archetypes"))
+	}
+	return paths
+}
+
+// Load reads the archetype in dir
+func Load(dir string) (*Archetype, error) {
+	data, err := os.ReadFile(filepath.Join(dir, DefinitionFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archetype: %w", err)
+	}
+
+	var a Archetype
+	if err := yaml.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, DefinitionFile), err)
+	}
+	if a.Name == "" {
+		a.Name = filepath.Base(dir)
+	}
+	a.Dir = dir
+	return &a, nil
+}
+
+// Resolve finds an archetype by path or name. A name is looked up in the
+// local search paths and then, if repo is set, in a shallow clone of that git
+// repository (at its root or in an archetypes folder). repo may end in @ref to
+// pick a branch or tag. The returned cleanup removes any clone.
+func Resolve(nameOrPath, repo string) (*Archetype, func(), error) {
+	noop := func() {}
+
+	if isDefinitionDir(nameOrPath) {
+		a, err := Load(nameOrPath)
+		return a, noop, err
+	}
+
+	var searched []string
+	for _, dir := range SearchPaths() {
+		candidate := filepath.Join(dir, nameOrPath)
+		if isDefinitionDir(candidate) {
+			a, err := Load(candidate)
+			return a, noop, err
+		}
+		searched = append(searched, dir)
+	}
+
+	if repo != "" {
+		url, ref := splitRef(repo)
+		cloneDir, err := os.MkdirTemp("", "now-sc-archetypes-")
+		if err != nil {
+			return nil, noop, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		cleanup := func() { os.RemoveAll(cloneDir) }
+
+		if err := git.Clone(url, ref, cloneDir); err != nil {
+			cleanup()
+			return nil, noop, fmt.Errorf("failed to fetch archetypes from %s: %w", repo, err)
+		}
+		for _, candidate := range []string{filepath.Join(cloneDir, nameOrPath), filepath.Join(cloneDir, "archetypes", nameOrPath)} {
+			if isDefinitionDir(candidate) {
+				a, err := Load(candidate)
+				if err != nil {
+					cleanup()
+					return nil, noop, err
+				}
+				return a, cleanup, nil
+			}
+		}
+		cleanup()
+		searched = append(searched, repo)
+	}
+
+	return nil, noop, fmt.Errorf("archetype %q not found (searched %s)", nameOrPath, strings.Join(searched, ", "))
+}
+
+// List returns the archetypes available in the local search paths
+func List() ([]*Archetype, error) {
+	var archetypes []*Archetype
+	seen := map[string]bool{}
+	for _, dir := range SearchPaths() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			candidate := filepath.Join(dir, entry.Name())
+			if !entry.IsDir() || seen[entry.Name()] || !isDefinitionDir(candidate) {
+				continue
+			}
+			a, err := Load(candidate)
+			if err != nil {
+				return nil, err
+			}
+			seen[entry.Name()] = true
+			archetypes = append(archetypes, a)
+		}
+	}
+	return archetypes, nil
+}
+
+// Layout returns base with the archetype's folders merged in
+func (a *Archetype) Layout(base *project.Layout) (*project.Layout, error) {
+	if len(a.Folders) == 0 {
+		return base, nil
+	}
+	layout := base.Merge(&project.Layout{Folders: a.Folders})
+	if err := layout.Validate(); err != nil {
+		return nil, fmt.Errorf("archetype %s: %w", a.Name, err)
+	}
+	return layout, nil
+}
+
+// Placeholders returns the placeholders available to seeded files. Archetype
+// variables are rendered too, so they can refer to the project and customer.
+func (a *Archetype) Placeholders(projectName, customerName string) map[string]string {
+	vars := map[string]string{
+		"{{project.name}}":   projectName,
+		"{{archetype.name}}": a.Name,
+		"{{date}}":           time.Now().Format("2006-01-02"),
+	}
+	for placeholder, value := range (&project.CustomerProfile{Name: customerName}).Placeholders() {
+		vars[placeholder] = value
+	}
+	for name, value := range a.Variables {
+		vars["{{"+name+"}}"] = Render(value, vars)
+	}
+	return vars
+}
+
+// Render replaces placeholders in s
+func Render(s string, vars map[string]string) string {
+	pairs := make([]string, 0, len(vars)*2)
+	for placeholder, value := range vars {
+		pairs = append(pairs, placeholder, value)
+	}
+	return strings.NewReplacer(pairs...).Replace(s)
+}
+
+// Seed renders the archetype's files into projectPath, replacing files the
+// base scaffold created. Paths may contain placeholders too. It returns the
+// written paths relative to projectPath.
+func (a *Archetype) Seed(projectPath string, vars map[string]string) ([]string, error) {
+	filesDir := filepath.Join(a.Dir, FilesDir)
+	if _, err := os.Stat(filesDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var written []string
+	err := filepath.WalkDir(filesDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(filesDir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(projectPath, Render(rel, vars))
+
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if isText(path) {
+			content = []byte(Render(string(content), vars))
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return err
+		}
+
+		relTarget, err := filepath.Rel(projectPath, target)
+		if err != nil {
+			return err
+		}
+		written = append(written, filepath.ToSlash(relTarget))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed files from archetype %s: %w", a.Name, err)
+	}
+	return written, nil
+}
+
+// KeepPrompts removes the prompt templates in promptsPath that the archetype
+// does not recommend. Archetypes without a prompt list keep everything.
+func (a *Archetype) KeepPrompts(promptsPath string) error {
+	if len(a.Prompts) == 0 {
+		return nil
+	}
+
+	keep := map[string]bool{}
+	for _, name := range a.Prompts {
+		keep[strings.ToLower(strings.TrimSuffix(name, ".md"))] = true
+	}
+
+	files, err := project.ListFiles(promptsPath)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		name := strings.ToLower(strings.TrimSuffix(path.Base(file), ".md"))
+		if keep[name] || keep[strings.ReplaceAll(name, "_", "-")] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(promptsPath, filepath.FromSlash(file))); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// WorkflowsMarkdown renders the archetype's workflows as a README section
+func (a *Archetype) WorkflowsMarkdown(vars map[string]string) string {
+	if len(a.Workflows) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString("## Workflows\n\n")
+	for _, w := range a.Workflows {
+		builder.WriteString(fmt.Sprintf("### %s\n\n", Render(w.Name, vars)))
+		if w.Description != "" {
+			builder.WriteString(Render(w.Description, vars) + "\n\n")
+		}
+		builder.WriteString("```bash\n")
+		for _, step := range w.Steps {
+			builder.WriteString(Render(step, vars) + "\n")
+		}
+		builder.WriteString("```\n\n")
+	}
+	return builder.String()
+}
+
+func isDefinitionDir(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, DefinitionFile))
+	return err == nil
+}
+
+// splitRef splits "url@ref" into its parts. An @ inside the host part of an
+// ssh URL such as git@github.com:org/repo is not a ref separator.
+func splitRef(repo string) (string, string) {
+	i := strings.LastIndex(repo, "@")
+	if i <= 0 || strings.ContainsAny(repo[i:], ":/") {
+		return repo, ""
+	}
+	return repo[:i], repo[i+1:]
+}
+
+// isText reports whether a seeded file should have placeholders rendered
+func isText(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".txt", ".html", ".htm", ".yaml", ".yml", ".json", ".csv":
+		return true
+	}
+	return false
+}
+
+// Names returns the names of archetypes, sorted
+func Names(archetypes []*Archetype) []string {
+	names := make([]string, len(archetypes))
+	for i, a := range archetypes {
+		names[i] = a.Name
+	}
+	sort.Strings(names)
+	return names
+}
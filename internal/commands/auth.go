@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/config"
+	"github.com/Now-AI-Foundry/Now-SC/internal/credentials"
+	"github.com/Now-AI-Foundry/Now-SC/internal/openrouter"
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var authSkipVerify bool
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Store and check provider credentials",
+	Long: `Store the OpenRouter API key and GitHub token in the OS keyring (Keychain,
+Credential Manager or the Secret Service) or, where no keyring is available, in
+a passphrase-encrypted file in the user config directory. Set
+credentials.store to keyring or file to choose, and ` + passphraseEnv + `
+to unlock the file without a prompt.
+
+Environment variables, .env and config files still take precedence over stored
+credentials.
+
+Subcommands:
+  login  - Store a credential for a provider
+  logout - Remove a stored credential
+  status - Show where each credential comes from and whether it works`,
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login <openrouter|github>",
+	Short: "Store a credential for a provider",
+	Long: `Asks for the credential, checks it with the provider and stores it. Without
+a terminal the credential is read from stdin, so it never appears in shell history:
+
+  now-sc auth login openrouter
+  gh auth token | now-sc auth login github`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAuthLogin,
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout <openrouter|github>",
+	Short: "Remove a stored credential",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAuthLogout,
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show where each credential comes from and whether it works",
+	RunE:  runAuthStatus,
+}
+
+func init() {
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+	authCmd.AddCommand(authStatusCmd)
+
+	authLoginCmd.Flags().BoolVar(&authSkipVerify, "skip-verify", false, "Store the credential without checking it with the provider")
+}
+
+func runAuthLogin(cmd *cobra.Command, args []string) error {
+	provider, err := credentials.FindProvider(args[0])
+	if err != nil {
+		return err
+	}
+
+	value, err := readCredential(provider)
+	if err != nil {
+		return err
+	}
+
+	if !authSkipVerify {
+		identity, err := verifyCredential(provider, value)
+		if err != nil {
+			color.Red("✗ %s was rejected: %v", provider.Label, err)
+			color.Yellow("Check the credential, or pass --skip-verify to store it anyway")
+			return fmt.Errorf("credential verification failed")
+		}
+		color.Green("✓ %s accepted%s", provider.Label, identity)
+	}
+
+	store, err := openCredentialStore()
+	if err != nil {
+		return err
+	}
+	if err := store.Set(provider.Name, value); err != nil {
+		return fmt.Errorf("failed to store credential: %w", err)
+	}
+	color.Green("✓ Stored %s in %s", provider.Label, store.Name())
+
+	// Other layers win over the store, which would make the login look ineffective
+	if current := settings.Lookup(provider.Setting); current.Source != config.SourceDefault {
+		color.Yellow("Note: %s is also set by %s, which takes precedence", provider.Setting, describeOrigin(current))
+	}
+	return nil
+}
+
+func runAuthLogout(cmd *cobra.Command, args []string) error {
+	provider, err := credentials.FindProvider(args[0])
+	if err != nil {
+		return err
+	}
+
+	store, err := openCredentialStore()
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(provider.Name); err != nil {
+		if errors.Is(err, credentials.ErrNotFound) {
+			color.Yellow("No %s is stored in %s", provider.Label, store.Name())
+			return nil
+		}
+		return fmt.Errorf("failed to remove credential: %w", err)
+	}
+	color.Green("✓ Removed %s from %s", provider.Label, store.Name())
+
+	if current := settings.Lookup(provider.Setting); current.Source != config.SourceDefault {
+		color.Yellow("Note: %s is still set by %s", provider.Setting, describeOrigin(current))
+	}
+	return nil
+}
+
+func runAuthStatus(cmd *cobra.Command, args []string) error {
+	for _, provider := range credentials.Providers {
+		value := secret(provider.Setting)
+		if value == "" {
+			color.Yellow("- %s: not configured (run now-sc auth login %s)", provider.Label, provider.Name)
+			continue
+		}
+
+		origin := describeOrigin(settings.Lookup(provider.Setting))
+		identity, err := verifyCredential(provider, value)
+		if err != nil {
+			color.Red("✗ %s from %s: %v", provider.Label, origin, err)
+			continue
+		}
+		color.Green("✓ %s from %s: %s%s", provider.Label, origin, config.Mask(value), identity)
+	}
+	return nil
+}
+
+// readCredential asks for a credential in a terminal or reads the first line of stdin
+func readCredential(provider credentials.Provider) (string, error) {
+	var value string
+	if isInteractive() {
+		prompt := promptui.Prompt{Label: provider.Label, Mask: '*'}
+		input, err := prompt.Run()
+		if err != nil {
+			return "", fmt.Errorf("input cancelled")
+		}
+		value = input
+	} else {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("failed to read %s from stdin: %w", provider.Label, err)
+		}
+		value = line
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("%s cannot be empty", provider.Label)
+	}
+	return value, nil
+}
+
+// verifyCredential checks a credential with its provider and describes who it belongs to
+func verifyCredential(provider credentials.Provider, value string) (string, error) {
+	switch provider.Name {
+	case "openrouter":
+		key, err := openrouter.NewClient(value).ValidateKey()
+		if err != nil {
+			return "", err
+		}
+		if key.Label != "" {
+			return fmt.Sprintf(" (%s)", key.Label), nil
+		}
+		return "", nil
+	case "github":
+		client := githubClient()
+		client.Token = value
+		info, err := client.CheckToken()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(" (%s)", info.Login), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,274 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/comms"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	commsCustomer  string
+	commsSet       []string
+	commsFiles     []string
+	commsOutput    string
+	commsPreview   bool
+	commsUseClaude bool
+	commsModel     string
+)
+
+var commsCmd = &cobra.Command{
+	Use:   "comms",
+	Short: "Prepare customer communications from templates",
+	Long: `Prepare customer communications from the templates in 30_CommunicationTemplates.
+
+Subcommands:
+  render - Fill a template from project data and save it as HTML and plain text`,
+}
+
+var commsRenderCmd = &cobra.Command{
+	Use:   "render <template>",
+	Short: "Fill a template from project data and save it as HTML and plain text",
+	Long: `Fills the {{...}} placeholders of a communication template and saves the result
+next to a plain-text alternative, ready to paste into an email.
+
+Placeholders are filled in this order:
+  --set name=value                      - given on the command line
+  {{project}}, {{date}}, {{project.created}}, {{action_items}}
+                                        - from the project and its saved outputs
+  {{customer.name}}, {{customer.industry}}, ...
+                                        - from the customer profile
+  anything else                         - written by the AI provider from the
+                                          latest outputs, action items, dates and
+                                          customer profile, plus any --file context
+
+The plain-text version is shown in the terminal before it is saved.
+
+Examples:
+  now-sc comms render servicenow_poc_status_template
+  now-sc comms render status/weekly_update --customer "Acme Corp" --preview
+  now-sc comms render servicenow_poc_status_template --set next_meeting="March 3" \
+    --file 00_Inbox/calls/external/latest.vtt`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCommsRender,
+}
+
+func init() {
+	commsRenderCmd.Flags().StringVar(&commsCustomer, "customer", "", "Customer whose profile and folder to use (default: the project's customer)")
+	commsRenderCmd.Flags().StringArrayVar(&commsSet, "set", []string{}, "Fill a placeholder as name=value (repeatable)")
+	commsRenderCmd.Flags().StringSliceVarP(&commsFiles, "file", "f", []string{}, "Extra context file(s), glob(s), directories or URLs for the AI provider")
+	commsRenderCmd.Flags().StringVarP(&commsOutput, "output", "o", "", "Output file path; the plain-text version is saved next to it as .txt")
+	commsRenderCmd.Flags().BoolVar(&commsPreview, "preview", false, "Show the result without saving it")
+	commsRenderCmd.Flags().BoolVar(&commsUseClaude, "claude", true, "Use Claude Code instead of OpenRouter (default: true)")
+	commsRenderCmd.Flags().StringVar(&commsModel, "model", "", "OpenRouter model to use")
+
+	// Add subcommands
+	commsCmd.AddCommand(commsRenderCmd)
+}
+
+func runCommsRender(cmd *cobra.Command, args []string) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return err
+	}
+	manifest, err := project.LoadManifest(projectRoot)
+	if err != nil {
+		return err
+	}
+	templatesDir, err := projectTemplatesDir(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	local, err := localTemplates(templatesDir)
+	if err != nil {
+		return err
+	}
+	template, err := findTemplate(local, args[0])
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(filepath.Join(templatesDir, filepath.FromSlash(template.Path)))
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+	isHTML := comms.IsHTML(template.Path)
+	color.Cyan("Using template: %s", template.Path)
+
+	var customer *project.CustomerProfile
+	customerName := commsCustomer
+	if customerName == "" {
+		customerName = manifest.Customer
+	}
+	if customerName != "" {
+		if customer, err = project.LoadCustomer(projectRoot, layout, customerName); err != nil {
+			return err
+		}
+		color.Cyan("Using customer profile: %s", customer.Name)
+	}
+
+	data, err := comms.Gather(projectRoot, layout, manifest, customer)
+	if err != nil {
+		return err
+	}
+	values := data.Values()
+	for _, assignment := range commsSet {
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid --set %q (expected name=value)", assignment)
+		}
+		values[strings.TrimSpace(name)] = value
+	}
+
+	placeholders := comms.Placeholders(string(content))
+	var missing []string
+	for _, name := range placeholders {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	fmt.Printf("Found %d placeholder(s), %d to write\n", len(placeholders), len(missing))
+	fmt.Println()
+
+	if len(missing) > 0 {
+		filled, err := fillFields(projectRoot, template.Name(), data, missing)
+		if err != nil {
+			return err
+		}
+		for _, name := range missing {
+			if value := filled[name]; value != "" {
+				values[name] = value
+			} else {
+				color.Yellow("Warning: no value for {{%s}}; fill it in before sending", name)
+			}
+		}
+	}
+
+	rendered := comms.Render(string(content), values, isHTML)
+	text := rendered
+	if isHTML {
+		text = comms.PlainText(rendered)
+	}
+
+	fmt.Println()
+	color.Cyan("Preview:")
+	fmt.Println("─────────────────────────────────────────")
+	fmt.Print(text)
+	fmt.Println("─────────────────────────────────────────")
+	fmt.Println()
+
+	if commsPreview {
+		fmt.Println("Preview only: nothing was saved.")
+		return nil
+	}
+
+	outputFile := commsOutput
+	if outputFile == "" {
+		outputFile = filepath.Join(projectRoot, commsOutputDir(layout, customer), template.Name()+"_"+time.Now().Format("2006-01-02")+path.Ext(template.Path))
+	}
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(outputFile, []byte(rendered), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	color.Green("✓ Saved to: %s", outputFile)
+	if isHTML {
+		textFile := strings.TrimSuffix(outputFile, filepath.Ext(outputFile)) + ".txt"
+		if err := os.WriteFile(textFile, []byte(text), 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+		color.Green("✓ Plain-text version saved to: %s", textFile)
+	}
+	return nil
+}
+
+// fillFields asks the AI provider for the placeholders project data cannot fill
+func fillFields(projectRoot, templateName string, data *comms.Data, fields []string) (map[string]string, error) {
+	var extraContext string
+	if len(commsFiles) > 0 {
+		files, err := ExpandInputs(commsFiles, InputOptions{})
+		if err != nil {
+			return nil, err
+		}
+		maxBytes, err := ParseSize(settings.Get("max_size"))
+		if err != nil {
+			return nil, err
+		}
+		if extraContext, err = FormatFileContext(projectRoot, files, maxBytes); err != nil {
+			return nil, fmt.Errorf("failed to read context files: %w", err)
+		}
+		color.Green("✓ Loaded %d context file(s)", len(files))
+	}
+
+	provider, providerName, err := newProvider(commsUseClaude, commsModel)
+	if err != nil {
+		return nil, err
+	}
+	redactor, err := loadRedactor(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Bool("redact") {
+		provider = redactingExecutor{provider: provider, redactor: redactor}
+	}
+
+	color.Cyan("Writing %s using %s (%d saved output(s), %d action item(s))...", strings.Join(fields, ", "), providerName, len(data.Outputs), len(data.ActionItems))
+	filled, err := comms.Fill(provider, templateName, data, extraContext, fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fill template with %s: %w", providerName, err)
+	}
+	return filled, nil
+}
+
+// commsOutputDir is where rendered communications are saved: the layout's
+// Communications folder, else the customer's folder, else the first save folder
+func commsOutputDir(layout *project.Layout, customer *project.CustomerProfile) string {
+	targets := layout.SaveTargets()
+	for _, target := range targets {
+		if strings.EqualFold(target.Label, "Communications") {
+			return target.Path
+		}
+	}
+	if customer != nil {
+		return filepath.Join(layout.Path(project.RoleCustomers), customer.Name)
+	}
+	if len(targets) > 0 {
+		return targets[0].Path
+	}
+	return "."
+}
+
+// localTemplates lists the communication templates in a project
+func localTemplates(dir string) ([]prompts.Template, error) {
+	files, err := project.ListFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var templates []prompts.Template
+	for _, file := range files {
+		if strings.HasPrefix(path.Base(file), ".") {
+			continue
+		}
+		category := path.Dir(file)
+		if category == "." {
+			category = ""
+		}
+		templates = append(templates, prompts.Template{Path: file, Category: category})
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Path < templates[j].Path })
+	return templates, nil
+}
@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/config"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configShowOrigin  bool
+	configShowSecrets bool
+	configProject     bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show and change configuration",
+	Long: `Settings are layered. Each layer overrides the ones before it:
+
+  1. built-in defaults
+  2. user config file (now-sc/config.yaml in $XDG_CONFIG_HOME, ~/.config by default)
+  3. project manifest (` + project.ManifestFile + `)
+  4. project .env
+  5. environment variables
+  6. command line flags
+
+Subcommands:
+  get  - Print the value of a setting
+  set  - Store a setting in the user config or the project manifest
+  list - List every setting`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Store a setting in the user config or, with --project, the project manifest",
+	Long: `Stores a setting in the user config file, or with --project in the project
+manifest. Secrets cannot be stored in the manifest because it is committed
+with the project.
+
+Examples:
+  now-sc config set provider openrouter
+  now-sc config set model anthropic/claude-3.5-sonnet --project
+  now-sc config set openrouter.api_key sk-or-...`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List every setting",
+	RunE:  runConfigList,
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configListCmd)
+
+	configGetCmd.Flags().BoolVar(&configShowOrigin, "show-origin", false, "Show where the value came from")
+	configGetCmd.Flags().BoolVar(&configShowSecrets, "show-secrets", false, "Print a secret instead of masking it")
+	configListCmd.Flags().BoolVar(&configShowOrigin, "show-origin", false, "Show where each value came from")
+	configListCmd.Flags().BoolVar(&configShowSecrets, "show-secrets", false, "Print secrets instead of masking them")
+	configSetCmd.Flags().BoolVar(&configProject, "project", false, "Store the setting in the project manifest")
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	key, err := config.FindKey(args[0])
+	if err != nil {
+		return err
+	}
+	if key.Secret {
+		secret(key.Name)
+	}
+
+	value := settings.Lookup(key.Name)
+	shown := value.Value
+	if key.Secret && !configShowSecrets {
+		shown = config.Mask(shown)
+	}
+	if configShowOrigin {
+		fmt.Printf("%s\t%s\n", describeOrigin(value), shown)
+	} else {
+		fmt.Println(shown)
+	}
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, err := config.FindKey(args[0])
+	if err != nil {
+		return err
+	}
+	value := args[1]
+	if err := key.Validate(value); err != nil {
+		return err
+	}
+
+	if configProject {
+		projectRoot, err := findProjectRoot()
+		if err != nil {
+			return err
+		}
+		manifest, err := project.LoadManifest(projectRoot)
+		if err != nil {
+			return err
+		}
+		if err := config.SetProject(manifest, key, value); err != nil {
+			return err
+		}
+		if err := manifest.Save(projectRoot); err != nil {
+			return err
+		}
+		color.Green("✓ Set %s in %s", key.Name, project.ManifestFile)
+	} else {
+		path, err := config.SetUser(key, value)
+		if err != nil {
+			return err
+		}
+		color.Green("✓ Set %s in %s", key.Name, path)
+	}
+
+	// A higher layer still wins, which is easy to miss
+	if current := settings.Lookup(key.Name); current.Source == config.SourceDotEnv || current.Source == config.SourceEnv ||
+		(!configProject && current.Source == config.SourceProject) {
+		color.Yellow("Note: %s is overridden by %s", key.Name, describeOrigin(current))
+	}
+	return nil
+}
+
+func runConfigList(cmd *cobra.Command, args []string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, name := range config.KeyNames() {
+		key, _ := config.FindKey(name)
+		value := settings.Lookup(name)
+
+		shown := value.Value
+		if key.Secret && !configShowSecrets {
+			shown = config.Mask(shown)
+		}
+		if configShowOrigin {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", name, shown, describeOrigin(value))
+		} else {
+			fmt.Fprintf(w, "%s\t%s\n", name, shown)
+		}
+	}
+	return w.Flush()
+}
+
+// describeOrigin names the layer a value came from
+func describeOrigin(value config.Value) string {
+	switch value.Source {
+	case config.SourceDefault:
+		return "default"
+	case config.SourceEnv:
+		return "env:" + value.Origin
+	case config.SourceFlag:
+		return "flag:" + value.Origin
+	case config.SourceCredentials:
+		return "credentials:" + value.Origin
+	default:
+		return "file:" + value.Origin
+	}
+}
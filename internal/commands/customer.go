@@ -0,0 +1,251 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	customerIndustry    string
+	customerRegion      string
+	customerInstanceURL string
+	customerContacts    []string
+)
+
+var customerCmd = &cobra.Command{
+	Use:   "customer",
+	Short: "Manage the customers of a project",
+	Long: `Manage customer folders and their profiles. Each customer gets a folder in
+01_Customers with a ` + project.CustomerProfileFile + ` profile (industry, region, contacts,
+ServiceNow instance URL) that "now-sc prompt run --customer <name>" injects into
+prompt templates.
+
+Subcommands:
+  add    - Create a customer folder and profile
+  list   - List customers
+  remove - Delete a customer folder`,
+}
+
+var customerAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Create a customer folder with a profile",
+	Long: `Creates a customer folder with a profile.
+
+Examples:
+  now-sc customer add "Acme Corp" --industry Manufacturing --region EMEA
+  now-sc customer add Globex --instance-url https://globex.service-now.com \
+    --contact "Jane Doe|CIO|jane@globex.com"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCustomerAdd,
+}
+
+var customerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the customers of the project",
+	RunE:  runCustomerList,
+}
+
+var customerRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Delete a customer folder and everything in it",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCustomerRemove,
+}
+
+func init() {
+	customerAddCmd.Flags().StringVar(&customerIndustry, "industry", "", "Customer industry")
+	customerAddCmd.Flags().StringVar(&customerRegion, "region", "", "Customer region")
+	customerAddCmd.Flags().StringVar(&customerInstanceURL, "instance-url", "", "Customer ServiceNow instance URL")
+	customerAddCmd.Flags().StringArrayVar(&customerContacts, "contact", []string{}, "Contact as \"Name|Role|Email|Phone\" (repeatable)")
+
+	// Add subcommands
+	customerCmd.AddCommand(customerAddCmd)
+	customerCmd.AddCommand(customerListCmd)
+	customerCmd.AddCommand(customerRemoveCmd)
+}
+
+func runCustomerAdd(cmd *cobra.Command, args []string) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(args[0])
+	if err := project.ValidateCustomerName(name); err != nil {
+		return err
+	}
+	if existing, err := project.LoadCustomer(projectRoot, layout, name); err == nil {
+		return fmt.Errorf("customer %s already exists", existing.Name)
+	}
+
+	profile := &project.CustomerProfile{
+		Name:        name,
+		Industry:    customerIndustry,
+		Region:      customerRegion,
+		InstanceURL: customerInstanceURL,
+	}
+	for _, c := range customerContacts {
+		contact, err := parseContact(c)
+		if err != nil {
+			return err
+		}
+		profile.Contacts = append(profile.Contacts, contact)
+	}
+
+	if err := profile.Save(projectRoot, layout); err != nil {
+		return err
+	}
+
+	color.Green("✓ Customer \"%s\" added", name)
+	fmt.Printf("  Profile: %s\n", filepath.Join(project.CustomerDir(projectRoot, layout, name), project.CustomerProfileFile))
+	return nil
+}
+
+// parseContact reads a "Name|Role|Email|Phone" contact; trailing fields are optional
+func parseContact(value string) (project.Contact, error) {
+	fields := strings.Split(value, "|")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	if fields[0] == "" || len(fields) > 4 {
+		return project.Contact{}, fmt.Errorf("invalid contact %q (expected \"Name|Role|Email|Phone\")", value)
+	}
+	for len(fields) < 4 {
+		fields = append(fields, "")
+	}
+	return project.Contact{Name: fields[0], Role: fields[1], Email: fields[2], Phone: fields[3]}, nil
+}
+
+func runCustomerList(cmd *cobra.Command, args []string) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	names, err := project.CustomerNames(projectRoot, layout)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		color.Yellow("No customers yet. Add one with \"now-sc customer add <name>\".")
+		return nil
+	}
+
+	fmt.Println()
+	color.Cyan("Customers:")
+	fmt.Println()
+
+	for _, name := range names {
+		profile, err := project.LoadCustomer(projectRoot, layout, name)
+		if err != nil {
+			color.Yellow("  %s (%v)", name, err)
+			continue
+		}
+
+		color.Green("  %s", profile.Name)
+		var details []string
+		for _, d := range []string{profile.Industry, profile.Region, profile.InstanceURL} {
+			if d != "" {
+				details = append(details, d)
+			}
+		}
+		if len(profile.Contacts) > 0 {
+			details = append(details, fmt.Sprintf("%d contact(s)", len(profile.Contacts)))
+		}
+		if len(details) > 0 {
+			fmt.Printf("    %s\n", color.New(color.Faint).Sprint(strings.Join(details, " · ")))
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func runCustomerRemove(cmd *cobra.Command, args []string) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	profile, err := project.LoadCustomer(projectRoot, layout, args[0])
+	if err != nil {
+		return err
+	}
+	dir := project.CustomerDir(projectRoot, layout, profile.Name)
+
+	// List everything that would be deleted before asking
+	var files []string
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(projectRoot, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the files of %s: %w", profile.Name, err)
+	}
+
+	manifest, err := project.LoadManifest(projectRoot)
+	if err != nil {
+		return err
+	}
+	isProjectCustomer := strings.EqualFold(manifest.Customer, profile.Name)
+
+	color.Yellow("Removing %s will delete %d file(s):", profile.Name, len(files))
+	for _, f := range files {
+		fmt.Printf("  %s\n", f)
+	}
+	if isProjectCustomer {
+		color.Yellow("%s is the project's customer and will be cleared from %s.", profile.Name, project.ManifestFile)
+	}
+	fmt.Println()
+
+	if ok, err := confirm(fmt.Sprintf("Delete customer %s", profile.Name)); err != nil {
+		return err
+	} else if !ok {
+		color.Yellow("Customer not removed.")
+		return nil
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove customer directory: %w", err)
+	}
+	if isProjectCustomer {
+		manifest.Customer = ""
+		if err := manifest.Save(projectRoot); err != nil {
+			return err
+		}
+	}
+
+	color.Green("✓ Customer \"%s\" removed", profile.Name)
+	return nil
+}
@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var doctorFix bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the project and environment for problems",
+	Long: `Checks the project and your environment and explains how to fix what it finds:
+
+  Project      - manifest, stored layout, missing folders, stray files, customer profiles
+  Templates    - prompt templates exist and their placeholders are valid
+  Providers    - Claude Code is installed, the OpenRouter API key is accepted
+  GitHub       - the GitHub token is valid and has the scopes repository creation needs
+  Git          - the project is a repository with a remote and nothing unpushed
+  Environment  - .env exists and is ignored by git
+
+Run with --fix to apply the fixes that can be made automatically (creating
+missing folders, restoring the stored layout and manifest, fetching prompts,
+creating .env from .env.example).`,
+	RunE:         runDoctor,
+	SilenceUsage: true,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Apply automatic fixes")
+}
+
+type checkStatus int
+
+const (
+	checkOK checkStatus = iota
+	checkWarn
+	checkFail
+)
+
+// checkResult is the outcome of a single doctor check
+type checkResult struct {
+	Name    string
+	Status  checkStatus
+	Message string
+	Fix     string       // What the user can do about it
+	Apply   func() error // Automatic fix run by --fix, nil if there is none
+}
+
+// checkGroup is a titled section of the doctor report
+type checkGroup struct {
+	Title   string
+	Results []checkResult
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	var groups []checkGroup
+
+	root, err := project.FindRoot(".")
+	if err != nil {
+		root = ""
+		groups = append(groups, checkGroup{Title: "Project", Results: []checkResult{{
+			Name:    "Project",
+			Status:  checkFail,
+			Message: "not inside a now-sc project",
+			Fix:     "cd into a project or run \"now-sc init\" to create one",
+		}}})
+	}
+
+	manifest := &project.Manifest{}
+	if root != "" {
+		var layout *project.Layout
+		var projectResults []checkResult
+		projectResults, manifest, layout = checkProject(root)
+		groups = append(groups, checkGroup{Title: "Project", Results: projectResults})
+		if layout != nil {
+			groups = append(groups, checkGroup{Title: "Templates", Results: checkTemplates(root, layout)})
+		}
+	}
+
+	groups = append(groups,
+		checkGroup{Title: "Providers", Results: checkProviders(manifest)},
+		checkGroup{Title: "GitHub", Results: checkGitHub()},
+	)
+	if root != "" {
+		groups = append(groups,
+			checkGroup{Title: "Git", Results: checkGit(root)},
+			checkGroup{Title: "Environment", Results: checkEnvironment(root)},
+		)
+	}
+
+	counts := map[checkStatus]int{}
+	fixable := 0
+	for _, group := range groups {
+		fmt.Println()
+		color.Cyan("%s", group.Title)
+
+		for _, result := range group.Results {
+			status := result.Status
+			if status != checkOK && result.Apply != nil && doctorFix {
+				if err := result.Apply(); err != nil {
+					printCheck(result)
+					color.Red("      fix failed: %v", err)
+				} else {
+					result.Status = checkOK
+					result.Message = "fixed: " + result.Message
+					printCheck(result)
+				}
+				counts[result.Status]++
+				continue
+			}
+
+			printCheck(result)
+			counts[status]++
+			if status != checkOK && result.Apply != nil {
+				fixable++
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("%d ok, %d warning(s), %d problem(s)\n", counts[checkOK], counts[checkWarn], counts[checkFail])
+	if fixable > 0 {
+		color.Yellow("Run \"now-sc doctor --fix\" to fix %d of them automatically.", fixable)
+	}
+
+	if counts[checkFail] > 0 {
+		return fmt.Errorf("doctor found %d problem(s)", counts[checkFail])
+	}
+	return nil
+}
+
+// printCheck prints a single result with its suggested fix
+func printCheck(result checkResult) {
+	var icon string
+	switch result.Status {
+	case checkOK:
+		icon = color.GreenString("✓")
+	case checkWarn:
+		icon = color.YellowString("!")
+	default:
+		icon = color.RedString("✗")
+	}
+
+	fmt.Printf("  %s %s: %s\n", icon, result.Name, result.Message)
+	if result.Status != checkOK && result.Fix != "" {
+		fmt.Printf("      → %s\n", result.Fix)
+	}
+}
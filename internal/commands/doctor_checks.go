@@ -0,0 +1,616 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/claude"
+	"github.com/Now-AI-Foundry/Now-SC/internal/git"
+	"github.com/Now-AI-Foundry/Now-SC/internal/github"
+	"github.com/Now-AI-Foundry/Now-SC/internal/openrouter"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+// projectFiles are the entries expected in a project root besides the layout folders
+var projectFiles = []string{project.ManifestFile, ".now-sc", ".git", ".gitignore", ".env", ".env.example", "README.md"}
+
+// placeholderPattern matches {{...}} placeholders in prompt templates
+var placeholderPattern = regexp.MustCompile(`\{\{([^{}]*)\}\}`)
+
+// checkProject validates the manifest, stored layout, folders and customer
+// profiles. The layout is nil when it cannot be loaded.
+func checkProject(root string) ([]checkResult, *project.Manifest, *project.Layout) {
+	var results []checkResult
+
+	layout, err := project.LoadProjectLayout(root)
+	if err != nil {
+		results = append(results, checkResult{
+			Name:    "Layout",
+			Status:  checkFail,
+			Message: err.Error(),
+			Fix:     fmt.Sprintf("fix or delete %s to fall back to the standard layout", project.LayoutFile),
+		})
+		return results, &project.Manifest{}, nil
+	}
+
+	manifest, err := project.LoadManifest(root)
+	switch {
+	case err != nil:
+		results = append(results, checkResult{
+			Name:    "Manifest",
+			Status:  checkFail,
+			Message: err.Error(),
+			Fix:     fmt.Sprintf("fix the YAML in %s", project.ManifestFile),
+		})
+		manifest = &project.Manifest{}
+	case !fileExists(filepath.Join(root, project.ManifestFile)):
+		results = append(results, checkResult{
+			Name:    "Manifest",
+			Status:  checkWarn,
+			Message: fmt.Sprintf("%s is missing", project.ManifestFile),
+			Fix:     "create one so commands can find the project root and its defaults",
+			Apply: func() error {
+				return inferManifest(root, layout).Save(root)
+			},
+		})
+	default:
+		results = append(results, checkResult{Name: "Manifest", Status: checkOK, Message: project.ManifestFile})
+	}
+
+	version, versionErr := project.ProjectLayoutVersion(root)
+	current, currentErr := project.LoadLayout()
+	if versionErr == nil && currentErr == nil && version < current.Version {
+		results = append(results, checkResult{
+			Name:    "Layout",
+			Status:  checkWarn,
+			Message: fmt.Sprintf("version %d, the current layout is version %d", version, current.Version),
+			Fix:     "run \"now-sc upgrade\"",
+		})
+	} else if fileExists(filepath.Join(root, project.LayoutFile)) {
+		results = append(results, checkResult{Name: "Layout", Status: checkOK, Message: fmt.Sprintf("version %d", layout.Version)})
+	} else {
+		results = append(results, checkResult{
+			Name:    "Layout",
+			Status:  checkWarn,
+			Message: fmt.Sprintf("%s is missing, using the standard layout", project.LayoutFile),
+			Fix:     "store the layout so later changes to the standard layout do not affect this project",
+			Apply: func() error {
+				return layout.Save(root)
+			},
+		})
+	}
+
+	var missing []string
+	for _, dir := range layout.Dirs() {
+		if !fileExists(filepath.Join(root, dir)) {
+			missing = append(missing, dir)
+		}
+	}
+	if len(missing) == 0 {
+		results = append(results, checkResult{Name: "Folders", Status: checkOK, Message: fmt.Sprintf("all %d folders present", len(layout.Dirs()))})
+	} else {
+		results = append(results, checkResult{
+			Name:    "Folders",
+			Status:  checkWarn,
+			Message: fmt.Sprintf("missing %s", strings.Join(slashPaths(missing), ", ")),
+			Fix:     "create the missing folders",
+			Apply: func() error {
+				for _, dir := range missing {
+					if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+						return fmt.Errorf("failed to create %s: %w", dir, err)
+					}
+				}
+				return nil
+			},
+		})
+	}
+
+	if stray, err := strayEntries(root, layout); err != nil {
+		results = append(results, checkResult{Name: "Stray files", Status: checkFail, Message: err.Error()})
+	} else if len(stray) > 0 {
+		inboxDir := layout.Path(project.RoleInboxUnsorted)
+		if inboxDir == "" {
+			inboxDir = layout.Path(project.RoleInbox)
+		}
+		results = append(results, checkResult{
+			Name:    "Stray files",
+			Status:  checkWarn,
+			Message: fmt.Sprintf("not part of the layout: %s", strings.Join(stray, ", ")),
+			Fix:     fmt.Sprintf("move them into %s and run \"now-sc inbox triage\"", filepath.ToSlash(inboxDir)),
+		})
+	}
+
+	results = append(results, checkCustomers(root, layout)...)
+
+	if _, err := loadRedactor(root); err != nil {
+		results = append(results, checkResult{
+			Name:    "Redaction",
+			Status:  checkFail,
+			Message: err.Error(),
+			Fix:     "fix the JSON or the regular expressions in the redaction config",
+		})
+	}
+
+	return results, manifest, layout
+}
+
+// checkCustomers verifies every customer profile parses
+func checkCustomers(root string, layout *project.Layout) []checkResult {
+	names, err := project.CustomerNames(root, layout)
+	if err != nil {
+		return []checkResult{{Name: "Customers", Status: checkFail, Message: err.Error()}}
+	}
+	if len(names) == 0 {
+		return []checkResult{{
+			Name:    "Customers",
+			Status:  checkWarn,
+			Message: "no customers",
+			Fix:     "run \"now-sc customer add <name>\"",
+		}}
+	}
+
+	var results []checkResult
+	for _, name := range names {
+		if _, err := project.LoadCustomer(root, layout, name); err != nil {
+			results = append(results, checkResult{
+				Name:    "Customer " + name,
+				Status:  checkFail,
+				Message: err.Error(),
+				Fix:     fmt.Sprintf("fix the YAML in %s", filepath.ToSlash(filepath.Join(layout.Path(project.RoleCustomers), name, project.CustomerProfileFile))),
+			})
+		}
+	}
+	if len(results) == 0 {
+		results = append(results, checkResult{Name: "Customers", Status: checkOK, Message: strings.Join(names, ", ")})
+	}
+	return results
+}
+
+// checkTemplates verifies prompt templates exist and their placeholders are valid
+func checkTemplates(root string, layout *project.Layout) []checkResult {
+	var results []checkResult
+
+	promptsDir := filepath.Join(root, layout.Path(project.RolePrompts))
+	refetchPrompts := func() error {
+		if err := os.MkdirAll(promptsDir, 0755); err != nil {
+			return fmt.Errorf("failed to create prompts directory: %w", err)
+		}
+		sources, err := projectPromptSources(root)
+		if err != nil {
+			return err
+		}
+		_, err = fetchPrompts(sources, promptsDir)
+		return err
+	}
+
+	if sources, err := projectPromptSources(root); err == nil {
+		described := make([]string, len(sources))
+		for i, source := range sources {
+			described[i] = source.String()
+			if source.Commit != "" {
+				described[i] += fmt.Sprintf(" (%.7s)", source.Commit)
+			}
+		}
+		results = append(results, checkResult{Name: "Prompt sources", Status: checkOK, Message: strings.Join(described, ", ")})
+	}
+	if manifest, err := project.LoadManifest(root); err == nil && manifest.PromptSnapshot != "" {
+		results = append(results, checkResult{
+			Name:    "Prompt sources",
+			Status:  checkWarn,
+			Message: fmt.Sprintf("prompts come from the snapshot built into now-sc (%s); run \"now-sc prompts sync\" once GitHub is reachable", manifest.PromptSnapshot),
+		})
+	}
+
+	var promptFiles []string
+	if files, err := project.ListFiles(promptsDir); err == nil {
+		for _, file := range files {
+			if strings.HasSuffix(file, ".md") {
+				promptFiles = append(promptFiles, filepath.Join(promptsDir, filepath.FromSlash(file)))
+			}
+		}
+	}
+	if len(promptFiles) == 0 {
+		results = append(results, checkResult{
+			Name:    "Prompts",
+			Status:  checkFail,
+			Message: fmt.Sprintf("no prompt templates in %s", filepath.ToSlash(layout.Path(project.RolePrompts))),
+			Fix:     "fetch the prompts from the project's prompt sources",
+			Apply:   refetchPrompts,
+		})
+	}
+
+	invalid := 0
+	for _, path := range promptFiles {
+		problems, err := templateProblems(path)
+		if err != nil {
+			problems = []string{err.Error()}
+		}
+		if len(problems) > 0 {
+			invalid++
+			results = append(results, checkResult{
+				Name:    filepath.ToSlash(strings.TrimPrefix(path, promptsDir+string(filepath.Separator))),
+				Status:  checkWarn,
+				Message: strings.Join(problems, "; "),
+				Fix:     "edit the template",
+			})
+		}
+	}
+	if len(promptFiles) > 0 && invalid == 0 {
+		results = append(results, checkResult{Name: "Prompts", Status: checkOK, Message: fmt.Sprintf("%d template(s)", len(promptFiles))})
+	}
+
+	if templatesPath := layout.Path(project.RoleCommunicationTemplates); templatesPath != "" {
+		templatesDir := filepath.Join(root, templatesPath)
+		files, _ := project.ListFiles(templatesDir)
+		if len(files) == 0 {
+			results = append(results, checkResult{
+				Name:    "Communication templates",
+				Status:  checkWarn,
+				Message: fmt.Sprintf("none in %s", filepath.ToSlash(templatesPath)),
+				Fix:     "fetch the communication templates from GitHub",
+				Apply: func() error {
+					if err := os.MkdirAll(templatesDir, 0755); err != nil {
+						return fmt.Errorf("failed to create templates directory: %w", err)
+					}
+					sources, err := projectPromptSources(root)
+					if err != nil {
+						return err
+					}
+					return pullTemplates(sources, templatesDir, nil, false)
+				},
+			})
+		} else {
+			results = append(results, checkResult{Name: "Communication templates", Status: checkOK, Message: fmt.Sprintf("%d file(s)", len(files))})
+		}
+	}
+
+	return results
+}
+
+// templateProblems lists what is wrong with a prompt template
+func templateProblems(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	var problems []string
+	if strings.TrimSpace(string(content)) == "" {
+		problems = append(problems, "template is empty")
+	}
+	if !utf8.Valid(content) {
+		problems = append(problems, "template is not valid UTF-8")
+	}
+
+	text := string(content)
+	matches := placeholderPattern.FindAllStringSubmatch(text, -1)
+	if strings.Count(text, "{{") != len(matches) {
+		problems = append(problems, "unterminated {{ placeholder")
+	}
+
+	known := (&project.CustomerProfile{}).Placeholders()
+	for _, match := range matches {
+		if strings.HasPrefix(strings.TrimSpace(match[1]), "customer") {
+			if _, ok := known[match[0]]; !ok {
+				problems = append(problems, fmt.Sprintf("unknown placeholder %s", match[0]))
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+// checkProviders verifies at least one AI provider can be used
+func checkProviders(manifest *project.Manifest) []checkResult {
+	var results []checkResult
+
+	hasClaude := claude.IsAvailable()
+	if hasClaude {
+		results = append(results, checkResult{Name: "Claude Code", Status: checkOK, Message: "installed"})
+	} else {
+		status := checkWarn
+		if settings.Get("provider") == project.ProviderClaude {
+			status = checkFail
+		}
+		results = append(results, checkResult{
+			Name:    "Claude Code",
+			Status:  status,
+			Message: "not installed",
+			Fix:     "install Claude Code from https://claude.ai/download",
+		})
+	}
+
+	hasOpenRouter := false
+	apiKey := secret("openrouter.api_key")
+	if apiKey == "" {
+		status := checkWarn
+		if settings.Get("provider") == project.ProviderOpenRouter {
+			status = checkFail
+		}
+		results = append(results, checkResult{
+			Name:    "OpenRouter",
+			Status:  status,
+			Message: "no OpenRouter API key configured",
+			Fix:     "get a key from https://openrouter.ai/ and run now-sc auth login openrouter, or export OPENROUTER_API_KEY",
+		})
+	} else if key, err := openrouter.NewClient(apiKey).ValidateKey(); err != nil {
+		results = append(results, checkResult{
+			Name:    "OpenRouter",
+			Status:  checkFail,
+			Message: err.Error(),
+			Fix:     "check OPENROUTER_API_KEY or create a new key at https://openrouter.ai/keys",
+		})
+	} else {
+		hasOpenRouter = true
+		message := "API key accepted"
+		if key.Label != "" {
+			message += fmt.Sprintf(" (%s)", key.Label)
+		}
+		if key.Limit != nil && key.Usage >= *key.Limit {
+			results = append(results, checkResult{
+				Name:    "OpenRouter",
+				Status:  checkWarn,
+				Message: fmt.Sprintf("credit limit reached (%.2f of %.2f used)", key.Usage, *key.Limit),
+				Fix:     "raise the key's limit or add credits at https://openrouter.ai/",
+			})
+		} else {
+			results = append(results, checkResult{Name: "OpenRouter", Status: checkOK, Message: message})
+		}
+	}
+
+	if !hasClaude && !hasOpenRouter {
+		results = append(results, checkResult{
+			Name:    "Provider",
+			Status:  checkFail,
+			Message: "no AI provider available",
+			Fix:     "install Claude Code or set a valid OPENROUTER_API_KEY",
+		})
+	}
+
+	return results
+}
+
+// checkGitHub verifies the GitHub token can create repositories
+func checkGitHub() []checkResult {
+	token := secret("github.token")
+	if token == "" {
+		return []checkResult{{
+			Name:    "Token",
+			Status:  checkWarn,
+			Message: "no GitHub token configured, repositories will not be created",
+			Fix:     "create a token with the repo scope and run now-sc auth login github, or export GITHUB_PAT",
+		}}
+	}
+
+	client := githubClient()
+	var results []checkResult
+	if client.APIURL != github.DefaultAPIURL || client.Uploads() != github.DefaultUploadURL {
+		results = append(results, checkResult{Name: "Server", Status: checkOK, Message: fmt.Sprintf("%s (uploads: %s)", client.APIURL, client.Uploads())})
+	}
+
+	info, err := client.CheckToken()
+	if err != nil {
+		return append(results, checkResult{
+			Name:    "Token",
+			Status:  checkFail,
+			Message: err.Error(),
+			Fix:     fmt.Sprintf("create a new token at %s/settings/tokens", client.WebURL()),
+		})
+	}
+
+	if info.FineGrained {
+		return append(results, checkResult{
+			Name:    "Token",
+			Status:  checkOK,
+			Message: fmt.Sprintf("fine-grained token for %s (make sure it has Administration and Contents write access)", info.Login),
+		})
+	}
+
+	for _, scope := range info.Scopes {
+		if scope == "repo" {
+			return append(results, checkResult{Name: "Token", Status: checkOK, Message: fmt.Sprintf("%s, scopes: %s", info.Login, strings.Join(info.Scopes, ", "))})
+		}
+	}
+	return append(results, checkResult{
+		Name:    "Token",
+		Status:  checkWarn,
+		Message: fmt.Sprintf("token for %s lacks the repo scope needed to create private repositories", info.Login),
+		Fix:     fmt.Sprintf("add the repo scope at %s/settings/tokens", client.WebURL()),
+	})
+}
+
+// checkGit reports the repository, remote and sync state of the project
+func checkGit(root string) []checkResult {
+	if !git.IsAvailable() {
+		return []checkResult{{Name: "Git", Status: checkWarn, Message: "git is not installed", Fix: "install git from https://git-scm.com/"}}
+	}
+	if !git.IsRepo(root) {
+		return []checkResult{{
+			Name:    "Repository",
+			Status:  checkWarn,
+			Message: "project is not a git repository",
+			Fix:     "run \"git init\" in the project root to track changes",
+		}}
+	}
+
+	var results []checkResult
+
+	if remote := git.RemoteURL(root, "origin"); remote == "" {
+		results = append(results, checkResult{
+			Name:    "Remote",
+			Status:  checkWarn,
+			Message: "no origin remote",
+			Fix:     "run \"git remote add origin <url>\"",
+		})
+	} else {
+		results = append(results, checkResult{Name: "Remote", Status: checkOK, Message: remote})
+	}
+
+	changes, err := git.Changes(root)
+	switch {
+	case err != nil:
+		results = append(results, checkResult{Name: "Working tree", Status: checkFail, Message: err.Error()})
+	case len(changes) > 0:
+		results = append(results, checkResult{
+			Name:    "Working tree",
+			Status:  checkWarn,
+			Message: fmt.Sprintf("%d uncommitted change(s)", len(changes)),
+			Fix:     "commit your work",
+		})
+	default:
+		results = append(results, checkResult{Name: "Working tree", Status: checkOK, Message: "clean"})
+	}
+
+	ahead, behind, ok := git.AheadBehind(root)
+	switch {
+	case !ok:
+		results = append(results, checkResult{
+			Name:    "Upstream",
+			Status:  checkWarn,
+			Message: "current branch has no upstream",
+			Fix:     "run \"git push -u origin HEAD\"",
+		})
+	case behind > 0:
+		results = append(results, checkResult{
+			Name:    "Upstream",
+			Status:  checkWarn,
+			Message: fmt.Sprintf("%d commit(s) behind", behind),
+			Fix:     "run \"git pull\"",
+		})
+	case ahead > 0:
+		results = append(results, checkResult{
+			Name:    "Upstream",
+			Status:  checkWarn,
+			Message: fmt.Sprintf("%d unpushed commit(s)", ahead),
+			Fix:     "run \"git push\"",
+		})
+	default:
+		results = append(results, checkResult{Name: "Upstream", Status: checkOK, Message: "up to date"})
+	}
+
+	return results
+}
+
+// checkEnvironment verifies .env exists and is not committed
+func checkEnvironment(root string) []checkResult {
+	var results []checkResult
+
+	envPath := filepath.Join(root, ".env")
+	examplePath := filepath.Join(root, ".env.example")
+	switch {
+	case fileExists(envPath):
+		results = append(results, checkResult{Name: ".env", Status: checkOK, Message: "present"})
+	case fileExists(examplePath):
+		results = append(results, checkResult{
+			Name:    ".env",
+			Status:  checkWarn,
+			Message: "missing",
+			Fix:     "copy .env.example to .env and fill in your keys",
+			Apply: func() error {
+				data, err := os.ReadFile(examplePath)
+				if err != nil {
+					return fmt.Errorf("failed to read .env.example: %w", err)
+				}
+				return os.WriteFile(envPath, data, 0600)
+			},
+		})
+	default:
+		results = append(results, checkResult{
+			Name:    ".env",
+			Status:  checkWarn,
+			Message: "missing",
+			Fix:     "create .env with OPENROUTER_API_KEY and GITHUB_PAT",
+		})
+	}
+
+	gitignorePath := filepath.Join(root, ".gitignore")
+	gitignore, _ := os.ReadFile(gitignorePath)
+	ignored := false
+	for _, line := range strings.Split(string(gitignore), "\n") {
+		if line = strings.TrimSpace(line); line == ".env" || line == "/.env" {
+			ignored = true
+			break
+		}
+	}
+	if ignored {
+		results = append(results, checkResult{Name: ".gitignore", Status: checkOK, Message: ".env is ignored"})
+	} else {
+		results = append(results, checkResult{
+			Name:    ".gitignore",
+			Status:  checkFail,
+			Message: ".env is not ignored, keys could be committed",
+			Fix:     "add .env to .gitignore",
+			Apply: func() error {
+				content := string(gitignore)
+				if content != "" && !strings.HasSuffix(content, "\n") {
+					content += "\n"
+				}
+				return os.WriteFile(gitignorePath, []byte(content+".env\n"), 0644)
+			},
+		})
+	}
+
+	return results
+}
+
+// inferManifest builds a manifest for a project created before manifests existed
+func inferManifest(root string, layout *project.Layout) *project.Manifest {
+	manifest := &project.Manifest{
+		Name:          filepath.Base(root),
+		Created:       time.Now().UTC().Truncate(time.Second),
+		LayoutVersion: layout.Version,
+		Provider:      project.ProviderClaude,
+	}
+	if sources, err := project.ParsePromptSources(settings.Get("prompts.sources")); err == nil {
+		manifest.Prompts = sources
+	}
+	if names, err := project.CustomerNames(root, layout); err == nil && len(names) == 1 {
+		manifest.Customer = names[0]
+	}
+	return manifest
+}
+
+// strayEntries lists entries in the project root that belong to neither the
+// layout nor the standard project files
+func strayEntries(root string, layout *project.Layout) ([]string, error) {
+	expected := map[string]bool{}
+	for _, name := range projectFiles {
+		expected[name] = true
+	}
+	for _, f := range layout.Folders {
+		expected[f.Name] = true
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project root: %w", err)
+	}
+
+	var stray []string
+	for _, entry := range entries {
+		if !expected[entry.Name()] {
+			stray = append(stray, entry.Name())
+		}
+	}
+	sort.Strings(stray)
+	return stray, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func slashPaths(paths []string) []string {
+	out := make([]string, len(paths))
+	for i, p := range paths {
+		out[i] = filepath.ToSlash(p)
+	}
+	return out
+}
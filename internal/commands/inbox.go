@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var inboxCmd = &cobra.Command{
+	Use:   "inbox",
+	Short: "Organize files in the project inbox",
+	Long: `Work with the files collected in the project inbox (00_Inbox by default).
+
+Subcommands:
+  triage  - Classify unsorted inbox files and move them into the right folder
+  status  - Show which files are new, processed or changed
+  archive - Move processed files out of the way`,
+}
+
+func init() {
+	// Add subcommands
+	inboxCmd.AddCommand(inboxTriageCmd)
+	inboxCmd.AddCommand(inboxStatusCmd)
+	inboxCmd.AddCommand(inboxArchiveCmd)
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/inbox"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var inboxArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Move processed inbox files into the archive",
+	Long: `Moves every inbox file that has been processed and not changed since into
+the ` + inbox.ArchiveDirName + ` folder of the inbox (00_Inbox/` + inbox.ArchiveDirName + ` with the default
+layout), keeping its folder below the inbox. Archived files are no longer
+offered by --discover or shown by "now-sc inbox status".`,
+	RunE: runInboxArchive,
+}
+
+func runInboxArchive(cmd *cobra.Command, args []string) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	files, err := DiscoverFiles(projectRoot)
+	if err != nil {
+		color.Red("Error: %v", err)
+		return err
+	}
+
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return err
+	}
+	inboxDir := layout.Path(project.RoleInbox)
+
+	state, err := inbox.LoadState(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	var processed []string
+	for _, file := range files {
+		status, err := state.Status(projectRoot, file.RelativePath)
+		if err != nil {
+			return err
+		}
+		if status == inbox.StatusProcessed {
+			processed = append(processed, file.RelativePath)
+		}
+	}
+
+	if len(processed) == 0 {
+		color.Yellow("No processed files to archive.")
+		return nil
+	}
+
+	color.Cyan("Processed files:")
+	for _, file := range processed {
+		fmt.Printf("  %s\n", file)
+	}
+	fmt.Println()
+
+	if ok, err := confirm(fmt.Sprintf("Archive %d file(s)", len(processed))); err != nil {
+		return err
+	} else if !ok {
+		color.Yellow("No files archived.")
+		return nil
+	}
+
+	archived := 0
+	for _, file := range processed {
+		if _, err := state.Archive(projectRoot, inboxDir, file); err != nil {
+			color.Red("✗ %v", err)
+			continue
+		}
+		archived++
+	}
+
+	if err := state.Save(projectRoot); err != nil {
+		return err
+	}
+
+	color.Green("✓ Archived %d file(s) to %s", archived, filepath.Join(inboxDir, inbox.ArchiveDirName))
+	return nil
+}
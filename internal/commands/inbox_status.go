@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/inbox"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var inboxStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which inbox files are new, processed or changed",
+	Long: `Lists every file in the inbox (00_Inbox) with its processing state:
+
+  new       - never used as context for a prompt
+  processed - used as context and unchanged since
+  changed   - modified since it was last processed
+
+Processing state is recorded in ` + inbox.StateFile + ` whenever
+"now-sc prompt run" uses an inbox file as context.`,
+	RunE: runInboxStatus,
+}
+
+func runInboxStatus(cmd *cobra.Command, args []string) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	files, err := DiscoverFiles(projectRoot)
+	if err != nil {
+		color.Red("Error: %v", err)
+		return err
+	}
+
+	state, err := inbox.LoadState(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		color.Yellow("Inbox is empty.")
+		return nil
+	}
+
+	counts := map[inbox.Status]int{}
+
+	fmt.Println()
+	color.Cyan("Inbox Status:")
+	fmt.Println()
+
+	for _, file := range files {
+		status, err := state.Status(projectRoot, file.RelativePath)
+		if err != nil {
+			return err
+		}
+		counts[status]++
+
+		var label string
+		switch status {
+		case inbox.StatusNew:
+			label = color.GreenString("%-9s", status)
+		case inbox.StatusChanged:
+			label = color.YellowString("%-9s", status)
+		default:
+			label = color.New(color.Faint).Sprintf("%-9s", status)
+		}
+		fmt.Printf("  %s  %s\n", label, file.RelativePath)
+
+		if record := state.Lookup(file.RelativePath); record != nil && len(record.Runs) > 0 {
+			last := record.Runs[len(record.Runs)-1]
+			detail := fmt.Sprintf("last run: %s at %s", last.Template, last.RanAt.Format("2006-01-02 15:04"))
+			if last.OutputPath != "" {
+				detail += fmt.Sprintf(" → %s", last.OutputPath)
+			}
+			if len(record.Runs) > 1 {
+				detail += fmt.Sprintf(" (%d runs)", len(record.Runs))
+			}
+			fmt.Printf("             %s\n", color.New(color.Faint).Sprint(detail))
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("%d new, %d processed, %d changed\n",
+		counts[inbox.StatusNew], counts[inbox.StatusProcessed], counts[inbox.StatusChanged])
+
+	return nil
+}
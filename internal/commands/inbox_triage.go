@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/inbox"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var triageUseClaude bool
+
+var inboxTriageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: "Classify unsorted inbox files and propose where to move them",
+	Long: `Classifies every unsorted file in the inbox (00_Inbox/notes and loose files
+in 00_Inbox with the default layout) as an internal call, external call, email,
+requirement document or pricing document using the configured AI provider, then
+proposes moving it into the matching layout folder or customer folder.
+
+Moves are applied after confirmation, or immediately with --yes.
+
+Examples:
+  now-sc inbox triage
+  now-sc inbox triage --yes --claude=false`,
+	RunE: runInboxTriage,
+}
+
+func init() {
+	inboxTriageCmd.Flags().BoolVar(&triageUseClaude, "claude", true, "Use Claude Code instead of OpenRouter (default: true)")
+}
+
+func runInboxTriage(cmd *cobra.Command, args []string) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	files, err := inbox.ListUntriaged(projectRoot, layout)
+	if err != nil {
+		color.Red("Error: %v", err)
+		return err
+	}
+
+	if len(files) == 0 {
+		color.Green("✓ Inbox is already sorted, nothing to triage")
+		return nil
+	}
+
+	customers, err := project.CustomerNames(projectRoot, layout)
+	if err != nil {
+		return err
+	}
+
+	provider, providerName, err := newProvider(triageUseClaude, "")
+	if err != nil {
+		return err
+	}
+
+	redactor, err := loadRedactor(projectRoot)
+	if err != nil {
+		return err
+	}
+	provider = redactingExecutor{provider: provider, redactor: redactor}
+
+	color.Cyan("Classifying %d file(s) using %s...", len(files), providerName)
+	fmt.Println()
+
+	var proposals []inbox.Proposal
+	for _, file := range files {
+		classification, err := inbox.Classify(provider, projectRoot, file, customers)
+		if err != nil {
+			color.Yellow("  ! %s: %v", file, err)
+			continue
+		}
+
+		proposal := inbox.Proposal{
+			Source:         file,
+			Destination:    inbox.Destination(*classification, customers, layout),
+			Classification: *classification,
+		}
+		printProposal(proposal)
+		if proposal.Destination != "" {
+			proposals = append(proposals, proposal)
+		}
+	}
+
+	fmt.Println()
+	if len(proposals) == 0 {
+		color.Yellow("No files to move.")
+		return nil
+	}
+
+	if ok, err := confirm(fmt.Sprintf("Move %d file(s)", len(proposals))); err != nil {
+		return err
+	} else if !ok {
+		color.Yellow("No files moved.")
+		return nil
+	}
+
+	state, err := inbox.LoadState(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	moved := 0
+	for _, proposal := range proposals {
+		newPath, err := inbox.Apply(projectRoot, proposal)
+		if err != nil {
+			color.Red("✗ %v", err)
+			continue
+		}
+		state.Move(proposal.Source, newPath)
+		fmt.Printf("  %s → %s\n", proposal.Source, newPath)
+		moved++
+	}
+
+	if err := state.Save(projectRoot); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	color.Green("✓ Moved %d of %d file(s)", moved, len(proposals))
+	return nil
+}
+
+func printProposal(p inbox.Proposal) {
+	destination := p.Destination
+	if destination == "" {
+		destination = color.New(color.Faint).Sprint("(stays in place)")
+	}
+	fmt.Printf("  %s\n", color.GreenString(p.Source))
+	fmt.Printf("    %-16s → %s\n", p.Classification.Category, destination)
+	if p.Classification.Reason != "" {
+		fmt.Printf("    %s\n", color.New(color.Faint).Sprint(p.Classification.Reason))
+	}
+}
@@ -4,25 +4,64 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
-	"github.com/Now-AI-Foundry/Now-SC/internal/github"
+	"github.com/Now-AI-Foundry/Now-SC/internal/archetype"
 	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	projectName  string
-	customerName string
-	noGitHub     bool
+	projectName       string
+	customerName      string
+	noGitHub          bool
+	initProvider      string
+	initModel         string
+	initExisting      string
+	initForce         bool
+	initArchetype     string
+	initArchetypeRepo string
+	initPromptSources string
+	initProtectBranch bool
+)
+
+// Ways of initializing into a directory that already exists
+const (
+	existingMerge  = "merge"
+	existingBackup = "backup"
+	existingAbort  = "abort"
 )
 
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize a new presales project",
 	Long: `Creates a new presales project with the standard directory structure,
-fetches base prompts from GitHub, and optionally creates a GitHub repository.`,
+fetches base prompts from GitHub, and optionally creates a GitHub repository.
+
+With a GitHub token the project is committed and pushed to a new repository;
+--protect-branch also blocks force pushes to and deletion of its main branch. A
+directory that is already a git repository keeps its history, and one with an
+origin remote gets no new repository.
+
+If the project directory already exists, nothing in it is deleted without
+being listed first. Choose how to continue:
+
+  merge  - create only the folders and files that are missing
+  backup - archive the directory to <name>-backup-<timestamp>.tar.gz, then start fresh
+  abort  - leave the directory alone
+
+Pass --existing to choose without being asked, or --force to back up and replace.
+
+--archetype starts from a scaffold for a kind of engagement (for example an
+ITSM POC or a CSM discovery): extra folders, seeded files, a recommended subset
+of prompts and suggested workflows. Archetypes are directories containing an
+` + archetype.DefinitionFile + `, looked up by path, in $NOW_SC_ORG_DIR/archetypes, in
+<user config dir>/now-sc/archetypes, or in the git repository given by
+--archetype-repo (or NOW_SC_ARCHETYPE_REPO).`,
 	RunE: runInit,
 }
 
@@ -30,14 +69,34 @@ func init() {
 	initCmd.Flags().StringVarP(&projectName, "name", "n", "", "Project name")
 	initCmd.Flags().StringVarP(&customerName, "customer", "c", "", "Customer name")
 	initCmd.Flags().BoolVar(&noGitHub, "no-github", false, "Skip GitHub repository creation")
+	initCmd.Flags().StringVar(&initProvider, "provider", project.ProviderClaude, "Preferred AI provider recorded in the project manifest (claude or openrouter)")
+	initCmd.Flags().StringVar(&initModel, "model", "", "Preferred OpenRouter model recorded in the project manifest")
+	initCmd.Flags().StringVar(&initExisting, "existing", "", "What to do if the directory exists: merge, backup or abort")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Back up and replace an existing directory without asking")
+	initCmd.Flags().StringVar(&initArchetype, "archetype", "", "Project archetype to scaffold from (name or directory)")
+	initCmd.Flags().StringVar(&initPromptSources, "prompt-source", "", "Prompt packs to fetch as owner/repo[/path][@ref], comma-separated, highest precedence first")
+	initCmd.Flags().StringVar(&initArchetypeRepo, "archetype-repo", "", "Git repository to fetch archetypes from, optionally ending in @ref")
+	initCmd.Flags().BoolVar(&initProtectBranch, "protect-branch", false, "Protect the main branch of the new GitHub repository")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
+	if initProvider != project.ProviderClaude && initProvider != project.ProviderOpenRouter {
+		return fmt.Errorf("unknown provider %q (expected %s or %s)", initProvider, project.ProviderClaude, project.ProviderOpenRouter)
+	}
+
 	// Interactive prompts if flags not provided
+	if projectName == "" && !isInteractive() {
+		return missingValue("project name", "--name")
+	}
+	if customerName == "" && !isInteractive() {
+		return missingValue("customer name", "--customer")
+	}
+
 	if projectName == "" {
 		prompt := promptui.Prompt{
-			Label:   "Project name",
-			Default: "presales-project",
+			Label:    "Project name",
+			Default:  "presales-project",
+			Validate: project.ValidateProjectName,
 		}
 		result, err := prompt.Run()
 		if err != nil {
@@ -62,84 +121,271 @@ func runInit(cmd *cobra.Command, args []string) error {
 		}
 		customerName = result
 	}
+	if err := project.ValidateProjectName(projectName); err != nil {
+		return err
+	}
 
-	projectPath := filepath.Join(".", projectName)
-
-	// Check if directory already exists
-	if _, err := os.Stat(projectPath); err == nil {
-		prompt := promptui.Prompt{
-			Label:     fmt.Sprintf("Directory %s already exists. Overwrite", projectName),
-			IsConfirm: true,
-		}
-		_, err := prompt.Run()
+	// Find the archetype before anything is created
+	var scaffold *archetype.Archetype
+	if initArchetype != "" {
+		resolved, cleanup, err := archetype.Resolve(initArchetype, initArchetypeRepo)
 		if err != nil {
-			color.Yellow("Project initialization cancelled.")
-			return nil
+			if available, listErr := archetype.List(); listErr == nil && len(available) > 0 {
+				color.Yellow("Available archetypes: %s", strings.Join(archetype.Names(available), ", "))
+			}
+			return err
 		}
+		defer cleanup()
+		scaffold = resolved
+		color.Cyan("Using archetype: %s", scaffold.Name)
+	}
 
-		if err := os.RemoveAll(projectPath); err != nil {
-			return fmt.Errorf("failed to remove existing directory: %w", err)
+	projectPath := filepath.Join(".", projectName)
+
+	// Handle an existing directory without losing anything in it
+	mode, err := existingDirMode(projectPath)
+	if err != nil {
+		return err
+	}
+	if mode == existingAbort {
+		color.Yellow("Project initialization cancelled.")
+		return nil
+	}
+
+	// Build the project next to its destination, which is only touched once
+	// the build has succeeded, so a failed init leaves nothing behind
+	buildPath, err := os.MkdirTemp(".", ".now-sc-init-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(buildPath)
+	if err := os.Chmod(buildPath, 0755); err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	layout, err := project.LoadLayout()
+	if err != nil {
+		return fmt.Errorf("failed to load project layout: %w", err)
+	}
+	if scaffold != nil {
+		if layout, err = scaffold.Layout(layout); err != nil {
+			return err
 		}
 	}
 
 	// Create project structure
 	fmt.Println(color.CyanString("Creating project structure..."))
-	if err := project.CreateStructure(projectPath, customerName); err != nil {
+	if err := project.CreateStructure(buildPath, customerName, layout); err != nil {
 		return fmt.Errorf("failed to create project structure: %w", err)
 	}
 
-	// Fetch prompts from GitHub
-	fmt.Println(color.CyanString("Fetching base prompts from GitHub..."))
-	if err := github.FetchAndSavePrompts(projectPath); err != nil {
-		return fmt.Errorf("failed to fetch prompts: %w", err)
+	manifest := &project.Manifest{
+		Name:          projectName,
+		Customer:      customerName,
+		Created:       time.Now().UTC().Truncate(time.Second),
+		LayoutVersion: layout.Version,
+		Provider:      initProvider,
+		Model:         initModel,
+	}
+	if scaffold != nil {
+		manifest.Archetype = scaffold.Name
 	}
 
-	// Fetch communication templates
-	fmt.Println(color.CyanString("Fetching communication templates..."))
-	if err := github.FetchCommunicationTemplates(projectPath); err != nil {
-		color.Yellow("\nWarning: Failed to fetch some templates")
+	// Fetch prompts from GitHub, pinning the commits they came from
+	sources, err := project.ParsePromptSources(settings.Get("prompts.sources"))
+	if err != nil {
+		return err
+	}
+	fmt.Println(color.CyanString("Fetching prompts from %s...", sources))
+	promptsPath := filepath.Join(buildPath, layout.Path(project.RolePrompts))
+	fetched, err := fetchPrompts(sources, promptsPath)
+	if err != nil {
+		color.Yellow("Without access to GitHub, import a bundle made with \"now-sc prompts export\" first.")
+		return err
+	}
+	manifest.Prompts, manifest.PromptSnapshot = fetched.Sources, fetched.Snapshot
+	if err := prompts.RecordBase(buildPath, promptsPath); err != nil {
+		return err
+	}
+	if err := manifest.Save(buildPath); err != nil {
+		return fmt.Errorf("failed to create project manifest: %w", err)
+	}
+	if scaffold != nil {
+		if err := scaffold.KeepPrompts(promptsPath); err != nil {
+			return err
+		}
+	}
+
+	// Fetch communication templates if the layout has a folder for them
+	if templatesDir := layout.Path(project.RoleCommunicationTemplates); templatesDir != "" {
+		fmt.Println(color.CyanString("Fetching communication templates..."))
+		if err := pullTemplates(manifest.Prompts, filepath.Join(buildPath, templatesDir), nil, false); err != nil {
+			color.Yellow("Warning: Failed to fetch communication templates: %v", err)
+			color.Yellow("Run \"now-sc templates pull\" in the project to retry.")
+		}
 	}
 
 	// Create project files
-	if err := project.CreateProjectFiles(projectPath, projectName, customerName); err != nil {
+	if err := project.CreateProjectFiles(buildPath, manifest, layout); err != nil {
 		return fmt.Errorf("failed to create project files: %w", err)
 	}
 
+	// Seed the archetype's files and workflows
+	if scaffold != nil {
+		vars := scaffold.Placeholders(projectName, customerName)
+		seeded, err := scaffold.Seed(buildPath, vars)
+		if err != nil {
+			return err
+		}
+		if workflows := scaffold.WorkflowsMarkdown(vars); workflows != "" {
+			if err := appendToFile(filepath.Join(buildPath, "README.md"), "\n"+workflows); err != nil {
+				return err
+			}
+		}
+		color.Green("✓ Seeded %d file(s) from archetype %s", len(seeded), scaffold.Name)
+	}
+
+	switch mode {
+	case existingBackup:
+		archive := project.BackupPath(projectPath, time.Now())
+		if err := project.Backup(projectPath, archive); err != nil {
+			return err
+		}
+		color.Green("✓ Backed up %s to %s", projectPath, archive)
+		if err := os.RemoveAll(projectPath); err != nil {
+			return fmt.Errorf("failed to remove existing directory: %w", err)
+		}
+		if err := os.Rename(buildPath, projectPath); err != nil {
+			return fmt.Errorf("failed to move the new project into place (restore from %s): %w", archive, err)
+		}
+	case existingMerge:
+		added, kept, err := project.MergeMissing(buildPath, projectPath)
+		if err != nil {
+			return fmt.Errorf("failed to merge into %s: %w", projectPath, err)
+		}
+		color.Green("✓ Added %d missing file(s) and folder(s) to %s", len(added), projectPath)
+		if len(kept) > 0 {
+			fmt.Printf("Kept existing: %s\n", strings.Join(kept, ", "))
+		}
+	default:
+		if err := os.Rename(buildPath, projectPath); err != nil {
+			return fmt.Errorf("failed to move the new project into place: %w", err)
+		}
+	}
+
 	color.Green("✓ Project \"%s\" created successfully!\n", projectName)
 
 	// Create GitHub repository if not skipped
-	githubToken := os.Getenv("GITHUB_PAT")
-	if !noGitHub && githubToken != "" {
-		fmt.Println(color.CyanString("Creating GitHub repository..."))
-		if err := github.CreateRepository(projectPath, projectName, customerName); err != nil {
-			color.Red("✗ GitHub repository creation failed: %v", err)
+	if noGitHub {
+		fmt.Println("\nSkipped GitHub repository creation.")
+	} else if secret("github.token") != "" {
+		fmt.Println()
+		if err := setupRepository(githubClient(), projectPath, projectName, customerName); err != nil {
+			color.Red("✗ GitHub repository setup failed: %v", err)
 			color.Yellow("You can create the repository manually later.")
-		} else {
-			color.Green("✓ GitHub repository created!")
 		}
-	} else if noGitHub {
-		fmt.Println("\nSkipped GitHub repository creation.")
 	} else {
-		color.Yellow("\nNote: GITHUB_PAT environment variable not set. Skipping GitHub repository creation.")
+		color.Yellow("\nNote: no GitHub token configured. Skipping GitHub repository creation.")
 		fmt.Println("To enable automatic repository creation, set your GitHub Personal Access Token:")
 		fmt.Println("  export GITHUB_PAT=your_token_here")
+		fmt.Println("  or: now-sc auth login github")
 	}
 
 	// Print summary
 	fmt.Println()
 	color.Cyan("Project structure created:")
 	fmt.Printf("  %s/\n", projectPath)
-	fmt.Println("  ├── 00_Inbox/")
-	fmt.Printf("  ├── 01_Customers/%s/\n", customerName)
-	fmt.Println("  ├── 10_PromptTemplates/")
-	fmt.Println("  ├── 20_Demo_Library/")
-	fmt.Println("  └── 99_Assets/")
+	for _, line := range layout.Tree(customerName) {
+		fmt.Printf("  %s\n", line)
+	}
 
 	fmt.Println()
 	color.Yellow("Next steps:")
 	fmt.Printf("  1. cd %s\n", projectName)
-	fmt.Println("  2. Set your OPENROUTER_API_KEY environment variable")
+	if initProvider == project.ProviderOpenRouter {
+		fmt.Println("  2. Set OPENROUTER_API_KEY in .env or your environment")
+	} else {
+		fmt.Println("  2. Make sure Claude Code is installed (or set OPENROUTER_API_KEY to use OpenRouter)")
+	}
 	fmt.Println("  3. Run \"now-sc prompt\" to execute prompts")
 
 	return nil
 }
+
+// existingDirMode decides what to do when the project directory already
+// exists, listing its contents before anything can be replaced. It returns
+// an empty string when the directory does not exist.
+func existingDirMode(projectPath string) (string, error) {
+	info, err := os.Stat(projectPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check %s: %w", projectPath, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s exists and is not a directory", projectPath)
+	}
+
+	files, err := project.ListFiles(projectPath)
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return existingMerge, nil
+	}
+
+	color.Yellow("Directory %s already exists and contains %d file(s):", projectPath, len(files))
+	for i, file := range files {
+		if i == 20 {
+			fmt.Printf("  ... and %d more\n", len(files)-20)
+			break
+		}
+		fmt.Printf("  %s\n", file)
+	}
+	fmt.Println()
+
+	mode := initExisting
+	if mode == "" && initForce {
+		mode = existingBackup
+	}
+	switch mode {
+	case existingMerge, existingBackup, existingAbort:
+		return mode, nil
+	case "":
+		if !isInteractive() {
+			return "", missingValue("what to do with the existing directory", "--existing merge|backup|abort or --force")
+		}
+	default:
+		return "", fmt.Errorf("unknown --existing mode %q (expected %s, %s or %s)", mode, existingMerge, existingBackup, existingAbort)
+	}
+
+	modes := []string{existingMerge, existingBackup, existingAbort}
+	modeSelect := promptui.Select{
+		Label: "How would you like to continue?",
+		Items: []string{
+			"Merge: create only the missing folders and files",
+			fmt.Sprintf("Back up to %s and start fresh", project.BackupPath(projectPath, time.Now())),
+			"Abort",
+		},
+	}
+	idx, _, err := modeSelect.Run()
+	if err != nil {
+		return existingAbort, nil
+	}
+	return modes[idx], nil
+}
+
+// appendToFile adds text to the end of a file
+func appendToFile(path, text string) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(text); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
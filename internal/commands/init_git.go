@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/git"
+	"github.com/Now-AI-Foundry/Now-SC/internal/github"
+	"github.com/fatih/color"
+)
+
+// defaultBranch is the branch new project repositories start on
+const defaultBranch = "main"
+
+// setupRepository creates the project's GitHub repository with client, makes
+// the initial commit and pushes it. A directory that is already a repository
+// keeps its history; one that already has an origin remote is left alone.
+func setupRepository(client *github.Client, projectPath, projectName, customerName string) error {
+	if !git.IsAvailable() {
+		return fmt.Errorf("git is not installed")
+	}
+	_, err := os.Stat(filepath.Join(projectPath, ".git"))
+	hasGit := err == nil
+	if hasGit {
+		if remote := git.RemoteURL(projectPath, "origin"); remote != "" {
+			fmt.Printf("Skipped GitHub repository creation: the directory is already a git repository with origin %s.\n", remote)
+			return nil
+		}
+	}
+
+	fmt.Println(color.CyanString("Creating GitHub repository..."))
+	repo, err := client.CreateRepository(projectName, customerName)
+	if err != nil {
+		return err
+	}
+	color.Green("✓ GitHub repository created: %s", repo.HTMLURL)
+
+	if !hasGit {
+		if err := git.Init(projectPath, defaultBranch); err != nil {
+			return err
+		}
+	}
+	if err := git.AddRemote(projectPath, "origin", repo.CloneURL); err != nil {
+		return err
+	}
+
+	if !git.HasCommits(projectPath) {
+		author := commitAuthor(projectPath, client)
+		message := fmt.Sprintf("Initial commit of %s\n\nPresales project for %s, created with now-sc %s.", projectName, customerName, rootCmd.Version)
+		if err := git.CommitAll(projectPath, message, author); err != nil {
+			return err
+		}
+		color.Green("✓ Created the initial commit")
+	} else if changes, err := git.Changes(projectPath); err == nil && len(changes) > 0 {
+		color.Yellow("The existing repository has %d uncommitted change(s); commit them and push again to publish them.", len(changes))
+	}
+
+	branch, err := git.CurrentBranch(projectPath)
+	if err != nil {
+		return err
+	}
+	if err := git.Push(projectPath, "origin", branch, client.Token); err != nil {
+		return fmt.Errorf("%w\nThe repository exists; push later with: git push -u origin %s", err, branch)
+	}
+	color.Green("✓ Pushed %s to origin", branch)
+
+	if settings.Bool("github.protect_branch") {
+		reviews, _ := strconv.Atoi(settings.Get("github.required_reviews"))
+		if err := client.ProtectBranch(repo.FullName, branch, reviews); err != nil {
+			color.Yellow("Warning: %v", err)
+		} else {
+			color.Green("✓ Protected %s", branch)
+		}
+	}
+	return nil
+}
+
+// commitAuthor returns the author of the initial commit: a zero Author when
+// git has an identity configured, else the token's GitHub account with its
+// no-reply address, else a placeholder
+func commitAuthor(projectPath string, client *github.Client) git.Author {
+	if _, ok := git.ConfiguredAuthor(projectPath); ok {
+		return git.Author{}
+	}
+	host := "github.com"
+	if web, err := url.Parse(client.WebURL()); err == nil {
+		host = web.Hostname()
+	}
+	if info, err := client.CheckToken(); err == nil && info.Login != "" {
+		return git.Author{Name: info.Login, Email: info.Login + "@users.noreply." + host}
+	}
+	return git.Author{Name: "now-sc", Email: "now-sc@users.noreply." + host}
+}
@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/config"
+	"github.com/Now-AI-Foundry/Now-SC/internal/git"
+	"github.com/Now-AI-Foundry/Now-SC/internal/github"
+)
+
+func TestSetupRepository(t *testing.T) {
+	if !git.IsAvailable() {
+		t.Skip("git is not installed")
+	}
+	// No git identity, so the commit is attributed to the token's account
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("NOW_SC_GITHUB_PROTECT_BRANCH", "true")
+	t.Setenv("NOW_SC_GITHUB_REQUIRED_REVIEWS", "1")
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings = cfg
+
+	// The "GitHub" remote is a local bare repository
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	if out, err := exec.Command("git", "init", "--quiet", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare: %v: %s", err, out)
+	}
+
+	var requests []string
+	var protection map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/user":
+			w.Write([]byte(`{"login":"octocat"}`))
+		case r.URL.Path == "/orgs/acme/repos":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"You need admin access to the organization before adding a repository to it."}`))
+		case r.URL.Path == "/user/repos":
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]string{"full_name": "octocat/deal", "clone_url": remote})
+		case r.URL.Path == "/repos/octocat/deal/branches/main/protection":
+			json.NewDecoder(r.Body).Decode(&protection)
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := github.NewClient("secret-token")
+	client.APIURL = srv.URL
+	client.Org = "acme"
+
+	projectPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectPath, "README.md"), []byte("# deal\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := setupRepository(client, projectPath, "deal", "Acme Corp"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"POST /orgs/acme/repos",
+		"POST /user/repos",
+		"GET /user",
+		"PUT /repos/octocat/deal/branches/main/protection",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(want, "\n"))
+	}
+
+	out, err := exec.Command("git", "-C", remote, "log", "--format=%an <%ae>|%s", "main").Output()
+	if err != nil {
+		t.Fatalf("nothing was pushed to main: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); !strings.HasPrefix(got, "octocat <octocat@users.noreply.127.0.0.1>|Initial commit of deal") {
+		t.Errorf("pushed commit = %q", got)
+	}
+
+	gitConfig, err := os.ReadFile(filepath.Join(projectPath, ".git", "config"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(gitConfig), "secret-token") {
+		t.Error("the token was written to .git/config")
+	}
+
+	reviews, _ := protection["required_pull_request_reviews"].(map[string]any)
+	if protection["allow_force_pushes"] != false || reviews["required_approving_review_count"] != float64(1) {
+		t.Errorf("protection = %v", protection)
+	}
+}
+
+func TestSetupRepositorySkipsExistingOrigin(t *testing.T) {
+	if !git.IsAvailable() {
+		t.Skip("git is not installed")
+	}
+	projectPath := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"remote", "add", "origin", "https://example.com/existing.git"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", projectPath}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+	client := github.NewClient("secret-token")
+	client.APIURL = srv.URL
+
+	if err := setupRepository(client, projectPath, "deal", "Acme Corp"); err != nil {
+		t.Fatal(err)
+	}
+}
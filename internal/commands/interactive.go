@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/manifoldco/promptui"
+	"golang.org/x/term"
+)
+
+var (
+	nonInteractive bool
+	assumeYes      bool
+)
+
+// isInteractive reports whether commands may prompt: --non-interactive was
+// not given and stdin is a terminal
+func isInteractive() bool {
+	if nonInteractive {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// confirm asks a yes/no question. --yes answers it without asking; without a
+// terminal it fails instead of waiting for an answer that cannot come.
+func confirm(label string) (bool, error) {
+	if assumeYes {
+		return true, nil
+	}
+	if !isInteractive() {
+		return false, fmt.Errorf("%q needs confirmation; rerun with --yes", label)
+	}
+
+	prompt := promptui.Prompt{
+		Label:     label,
+		IsConfirm: true,
+	}
+	if _, err := prompt.Run(); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// missingValue is the error for a required value that cannot be asked for
+func missingValue(what, flag string) error {
+	return fmt.Errorf("%s is required in non-interactive mode; pass %s", what, flag)
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/fatih/color"
+)
+
+// findProjectRoot locates the project containing the working directory
+func findProjectRoot() (string, error) {
+	root, err := project.FindRoot(".")
+	if err != nil {
+		color.Red("Error: %v", err)
+		return "", err
+	}
+	return root, nil
+}
+
+// projectPromptSources returns the prompt sources pinned in a project's
+// manifest, or the configured ones for projects that do not record any
+func projectPromptSources(projectRoot string) (project.PromptSources, error) {
+	manifest, err := project.LoadManifest(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Prompts) > 0 {
+		return manifest.Prompts, nil
+	}
+	return project.ParsePromptSources(settings.Get("prompts.sources"))
+}
+
+// fetchPrompts writes the prompt packs of sources into dir and returns the
+// sources with the commits they were fetched at. Cached copies used because
+// GitHub could not be reached are reported.
+func fetchPrompts(sources project.PromptSources, dir string) (*prompts.FetchResult, error) {
+	result, err := prompts.Fetch(githubClient(), sources, dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, stale := range result.Stale {
+		color.Yellow("Warning: %s", stale)
+	}
+	return result, nil
+}
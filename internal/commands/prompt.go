@@ -9,6 +9,7 @@ import (
 
 	"github.com/Now-AI-Foundry/Now-SC/internal/claude"
 	"github.com/Now-AI-Foundry/Now-SC/internal/openrouter"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
@@ -21,8 +22,11 @@ var promptCmd = &cobra.Command{
 Supports Claude Code integration and OpenRouter API.
 
 Subcommands:
-  list - List all available prompts
-  run  - Execute a specific prompt by name
+  list   - List all available prompts
+  run    - Execute a specific prompt by name
+  sync   - Update prompts from their sources, keeping local edits
+  export - Save prompt packs to a bundle for machines without GitHub access
+  import - Add prompt packs from a bundle to the local cache
 
 Interactive mode (default):
   now-sc prompt
@@ -30,18 +34,26 @@ Interactive mode (default):
 Direct execution:
   now-sc prompt run <name>
   cat file.txt | now-sc prompt run <name>`,
-	RunE: runPrompt,
+	Aliases: []string{"prompts"},
+	RunE:    runPrompt,
 }
 
 func init() {
 	// Add subcommands
 	promptCmd.AddCommand(promptListCmd)
 	promptCmd.AddCommand(promptRunCmd)
+	promptCmd.AddCommand(promptSyncCmd)
+	promptCmd.AddCommand(promptExportCmd)
+	promptCmd.AddCommand(promptImportCmd)
 }
 
 func runPrompt(cmd *cobra.Command, args []string) error {
+	if !isInteractive() {
+		return fmt.Errorf("interactive prompt selection needs a terminal; use \"now-sc prompt run <name>\" with --file and --output instead")
+	}
+
 	// Check for Claude Code or API key
-	apiKey := os.Getenv("OPENROUTER_API_KEY")
+	apiKey := secret("openrouter.api_key")
 	hasClaudeCode := claude.IsAvailable()
 
 	if apiKey == "" && !hasClaudeCode {
@@ -52,42 +64,47 @@ func runPrompt(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no AI provider configured")
 	}
 
-	// Determine which provider to use
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		color.Yellow("Make sure you are in a project created with \"now-sc init\"")
+		return err
+	}
+
+	manifest, err := project.LoadManifest(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	// Determine which provider to use, preferring the project's choice
 	useClaudeCode := hasClaudeCode
-	if apiKey != "" && !hasClaudeCode {
+	if apiKey != "" && (!hasClaudeCode || settings.Get("provider") == project.ProviderOpenRouter) {
 		useClaudeCode = false
 	}
 
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return err
+	}
+
 	// Find prompt templates directory
-	promptsPath := filepath.Join(".", "10_PromptTemplates")
+	promptsPath := filepath.Join(projectRoot, layout.Path(project.RolePrompts))
 	if _, err := os.Stat(promptsPath); os.IsNotExist(err) {
-		color.Red("Error: No prompt templates directory found in current directory")
+		color.Red("Error: No prompt templates directory found in %s", projectRoot)
 		color.Yellow("Make sure you are in a project created with \"now-sc init\"")
 		return fmt.Errorf("prompt templates directory not found")
 	}
 
 	// List available prompts
-	entries, err := os.ReadDir(promptsPath)
+	promptInfos, err := ListPrompts(projectRoot)
 	if err != nil {
-		return fmt.Errorf("failed to read prompts directory: %w", err)
-	}
-
-	var promptFiles []string
-	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
-			promptFiles = append(promptFiles, entry.Name())
-		}
-	}
-
-	if len(promptFiles) == 0 {
 		color.Red("Error: No prompt templates found")
-		return fmt.Errorf("no prompt templates found")
+		return err
 	}
 
 	// Let user select a prompt
-	templates := make([]string, len(promptFiles))
-	for i, file := range promptFiles {
-		templates[i] = strings.TrimSuffix(strings.ReplaceAll(file, "_", " "), ".md")
+	templates := make([]string, len(promptInfos))
+	for i, info := range promptInfos {
+		templates[i] = info.DisplayName()
 	}
 
 	promptSelect := promptui.Select{
@@ -100,10 +117,10 @@ func runPrompt(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("prompt selection failed: %w", err)
 	}
 
-	selectedPrompt := promptFiles[idx]
+	selectedPrompt := promptInfos[idx]
 
 	// Read the prompt content
-	promptContent, err := os.ReadFile(filepath.Join(promptsPath, selectedPrompt))
+	promptContent, err := os.ReadFile(selectedPrompt.Path)
 	if err != nil {
 		return fmt.Errorf("failed to read prompt file: %w", err)
 	}
@@ -132,6 +149,7 @@ func runPrompt(cmd *cobra.Command, args []string) error {
 
 	// Execute prompt
 	var result string
+	modelUsed := "Claude Code"
 	if useClaudeCode {
 		color.Cyan("Using Claude Code...")
 		claudeClient := claude.NewClient()
@@ -142,6 +160,8 @@ func runPrompt(cmd *cobra.Command, args []string) error {
 	} else {
 		color.Cyan("Using OpenRouter...")
 		client := openrouter.NewClient(apiKey)
+		client.SetModel(manifest.Model)
+		modelUsed = client.Model()
 		result, err = client.ExecutePrompt(string(promptContent), userInput)
 		if err != nil {
 			return fmt.Errorf("failed to execute prompt with OpenRouter: %w", err)
@@ -171,48 +191,13 @@ func runPrompt(cmd *cobra.Command, args []string) error {
 	}
 
 	// Select output location
-	locations := []string{
-		"Project Overview (99_Assets/Project_Overview)",
-		"Communications (99_Assets/Communications)",
-		"POC Documents (99_Assets/POC_Documents)",
-		"Notes (00_Inbox/notes)",
-		"Other (specify)",
-	}
-
-	locationSelect := promptui.Select{
-		Label: "Where would you like to save the output?",
-		Items: locations,
-	}
-
-	locIdx, _, err := locationSelect.Run()
+	savePath, err := selectSaveLocation(layout, "")
 	if err != nil {
 		return nil
 	}
 
-	var savePath string
-	switch locIdx {
-	case 0:
-		savePath = "99_Assets/Project_Overview"
-	case 1:
-		savePath = "99_Assets/Communications"
-	case 2:
-		savePath = "99_Assets/POC_Documents"
-	case 3:
-		savePath = "00_Inbox/notes"
-	case 4:
-		promptCustom := promptui.Prompt{
-			Label:   "Enter the path (relative to project root)",
-			Default: "99_Assets",
-		}
-		customPath, err := promptCustom.Run()
-		if err != nil {
-			return nil
-		}
-		savePath = customPath
-	}
-
 	// Get filename
-	defaultFilename := strings.TrimSuffix(selectedPrompt, ".md") + "_" + time.Now().Format("2006-01-02")
+	defaultFilename := strings.TrimSuffix(selectedPrompt.FileName, ".md") + "_" + time.Now().Format("2006-01-02")
 	promptFilename := promptui.Prompt{
 		Label:   "Enter filename (without extension)",
 		Default: defaultFilename,
@@ -246,12 +231,12 @@ func runPrompt(cmd *cobra.Command, args []string) error {
 `, strings.ReplaceAll(filename, "_", " "),
 		time.Now().Format("2006-01-02 15:04:05"),
 		selectedPrompt,
-		"google/gemini-2.0-flash-exp:free",
+		modelUsed,
 		userInput,
 		result)
 
 	// Save to file
-	fullPath := filepath.Join(".", savePath, filename+".md")
+	fullPath := filepath.Join(projectRoot, savePath, filename+".md")
 	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
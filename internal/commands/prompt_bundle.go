@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var exportPromptSources string
+
+var promptExportCmd = &cobra.Command{
+	Use:   "export <bundle>",
+	Short: "Save prompt packs to a bundle for machines without GitHub access",
+	Long: `Downloads the prompt packs of the current project's prompt sources, or of the
+configured ones outside a project, and writes them with the commits they
+resolved to into a single bundle file. Import the bundle on an air-gapped
+machine to create projects there.
+
+Examples:
+  now-sc prompts export packs.tar.gz
+  now-sc prompts export packs.tar.gz --prompt-source acme/prompts@v2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPromptExport,
+}
+
+var promptImportCmd = &cobra.Command{
+	Use:   "import <bundle>",
+	Short: "Add prompt packs from a bundle to the local cache",
+	Long: `Checks each prompt pack in a bundle made by "now-sc prompts export" and adds
+it to the local cache. "now-sc init" and "now-sc prompts sync" then use the
+cached packs when GitHub cannot be reached.
+
+Example:
+  now-sc prompts import packs.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPromptImport,
+}
+
+func init() {
+	promptExportCmd.Flags().StringVar(&exportPromptSources, "prompt-source", "", "Prompt sources to export (owner/repo[/path][@ref], comma-separated)")
+}
+
+func runPromptExport(cmd *cobra.Command, args []string) error {
+	sources, err := project.ParsePromptSources(settings.Get("prompts.sources"))
+	if err != nil {
+		return err
+	}
+	// A project exports the commits it is pinned to unless told otherwise
+	if projectRoot, err := project.FindRoot("."); err == nil && !cmd.Flags().Changed("prompt-source") {
+		if sources, err = projectPromptSources(projectRoot); err != nil {
+			return err
+		}
+	}
+
+	cache, err := prompts.OpenCache(githubClient())
+	if err != nil {
+		return err
+	}
+	fmt.Println(color.CyanString("Exporting prompts from %s...", sources))
+	result, err := cache.Export(sources, args[0])
+	if err != nil {
+		return err
+	}
+	for _, stale := range result.Stale {
+		color.Yellow("Warning: %s", stale)
+	}
+	for _, source := range result.Sources {
+		fmt.Printf("  %s at %.7s\n", source.Repository, source.Commit)
+	}
+	color.Green("✓ Wrote %d prompt pack(s) to %s", len(result.Sources), args[0])
+	return nil
+}
+
+func runPromptImport(cmd *cobra.Command, args []string) error {
+	cache, err := prompts.OpenCache(githubClient())
+	if err != nil {
+		return err
+	}
+	sources, err := cache.Import(args[0])
+	if err != nil {
+		return err
+	}
+	for _, source := range sources {
+		fmt.Printf("  %s at %.7s\n", source, source.Commit)
+	}
+	color.Green("✓ Imported %d prompt pack(s) into %s", len(sources), cache.Dir)
+	return nil
+}
@@ -0,0 +1,301 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StdinInput is the --file value that reads context from standard input
+const StdinInput = "-"
+
+// InputOptions controls how --file arguments are expanded
+type InputOptions struct {
+	Include []string // Patterns a file found via a directory or glob must match
+	Exclude []string // Patterns that drop a file found via a directory or glob
+}
+
+// ExpandInputs resolves --file arguments into a deterministic list of inputs.
+// Each argument may be a literal path, a glob (with ** for any number of
+// directories), a directory (walked recursively), "-" for stdin or an
+// http(s) URL. Arguments keep their order; matches within a glob or
+// directory are sorted, and duplicates are dropped.
+func ExpandInputs(args []string, opts InputOptions) ([]string, error) {
+	var inputs []string
+	seen := map[string]bool{}
+	add := func(input string) {
+		if !seen[input] {
+			seen[input] = true
+			inputs = append(inputs, input)
+		}
+	}
+
+	for _, arg := range args {
+		switch {
+		case arg == StdinInput || isURL(arg):
+			add(arg)
+
+		case hasGlobMeta(arg):
+			matches, err := expandGlob(arg, opts)
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %s", arg)
+			}
+			for _, match := range matches {
+				add(match)
+			}
+
+		default:
+			info, err := os.Stat(arg)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read file %s: %w", arg, err)
+			}
+			if !info.IsDir() {
+				add(filepath.Clean(arg))
+				continue
+			}
+			matches, err := walkInputs(arg, func(rel string) bool { return opts.allows(rel) })
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files found in directory %s", arg)
+			}
+			for _, match := range matches {
+				add(match)
+			}
+		}
+	}
+
+	return inputs, nil
+}
+
+// ParseSize parses sizes such as "512KB", "2MB" or a plain byte count
+func ParseSize(value string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		factor int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			multiplier = unit.factor
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+	return n * multiplier, nil
+}
+
+// formatSize renders a byte count for messages
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
+
+// readInput returns the content of a single expanded input, reading at
+// most limit+1 bytes so callers can detect inputs over the size cap
+func readInput(input string, limit int64) ([]byte, error) {
+	var reader io.Reader
+
+	switch {
+	case input == StdinInput:
+		reader = os.Stdin
+
+	case isURL(input):
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Get(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", input, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch %s: status %d", input, resp.StatusCode)
+		}
+		reader = resp.Body
+
+	default:
+		f, err := os.Open(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", input, err)
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	content, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", input, err)
+	}
+	return content, nil
+}
+
+// inputLabel names an input in the prompt context: project-relative for
+// files so identically named notes in different folders stay distinct
+func inputLabel(projectRoot, input string) string {
+	if input == StdinInput {
+		return "stdin"
+	}
+	if isURL(input) {
+		return input
+	}
+
+	absRoot, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return input
+	}
+	absInput, err := filepath.Abs(input)
+	if err != nil {
+		return input
+	}
+	rel, err := filepath.Rel(absRoot, absInput)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return absInput
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (o InputOptions) allows(rel string) bool {
+	if len(o.Include) > 0 && !matchesAny(o.Include, rel) {
+		return false
+	}
+	return !matchesAny(o.Exclude, rel)
+}
+
+// matchesAny reports whether a slash-separated relative path matches one of
+// the patterns. Patterns without a slash are matched against the file name.
+func matchesAny(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		pattern = filepath.ToSlash(pattern)
+		target := rel
+		if !strings.Contains(pattern, "/") {
+			target = pathBase(rel)
+		}
+		if matchGlob(pattern, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandGlob walks the static prefix of a pattern and returns the sorted
+// files whose path matches it
+func expandGlob(pattern string, opts InputOptions) ([]string, error) {
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+
+	var base []string
+	for len(segments) > 0 && !hasGlobMeta(segments[0]) {
+		base = append(base, segments[0])
+		segments = segments[1:]
+	}
+
+	root := strings.Join(base, "/")
+	if root == "" {
+		root = "."
+	}
+
+	rest := strings.Join(segments, "/")
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	return walkInputs(filepath.FromSlash(root), func(rel string) bool {
+		return matchGlob(rest, rel) && opts.allows(rel)
+	})
+}
+
+// walkInputs returns the sorted files below dir, skipping hidden entries,
+// for which keep returns true given the slash-separated path relative to dir
+func walkInputs(dir string, keep func(rel string) bool) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if keep(filepath.ToSlash(rel)) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// matchGlob matches a slash-separated path against a pattern in which **
+// matches any number of path segments and other segments use filepath.Match
+func matchGlob(pattern, path string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(path, "/"))
+}
+
+func matchSegments(pattern, path []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(path); i++ {
+				if matchSegments(pattern[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(path) == 0 {
+			return false
+		}
+		if ok, err := filepath.Match(pattern[0], path[0]); err != nil || !ok {
+			return false
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0
+}
+
+func hasGlobMeta(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+func pathBase(rel string) string {
+	if i := strings.LastIndex(rel, "/"); i >= 0 {
+		return rel[i+1:]
+	}
+	return rel
+}
@@ -15,8 +15,11 @@ var promptListCmd = &cobra.Command{
 }
 
 func runPromptList(cmd *cobra.Command, args []string) error {
-	// Get current directory as project root
-	projectRoot := "."
+	// Find the project containing the current directory
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
 
 	// List all prompts
 	prompts, err := ListPrompts(projectRoot)
@@ -32,7 +35,7 @@ func runPromptList(cmd *cobra.Command, args []string) error {
 
 	for i, prompt := range prompts {
 		fmt.Printf("%2d. ", i+1)
-		color.Green(prompt.Name)
+		color.Green(prompt.DisplayName())
 		if prompt.Description != "" {
 			fmt.Printf("    %s\n", color.New(color.Faint).Sprint(prompt.Description))
 		} else {
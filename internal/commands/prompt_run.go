@@ -8,19 +8,30 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Now-AI-Foundry/Now-SC/internal/claude"
+	"github.com/Now-AI-Foundry/Now-SC/internal/inbox"
+	"github.com/Now-AI-Foundry/Now-SC/internal/media"
+	"github.com/Now-AI-Foundry/Now-SC/internal/openrouter"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	inputFiles      []string
-	useClaudeCode   bool
-	discoverFiles   bool
-	saveOutput      bool
-	outputPath      string
-	stdinInput      bool
+	inputFiles    []string
+	useClaudeCode bool
+	discoverFiles bool
+	saveOutput    bool
+	outputPath    string
+	stdinInput    bool
+	onlyNewFiles  bool
+	includeFiles  []string
+	excludeFiles  []string
+	maxSize       string
+	modelName     string
+	redactInput   bool
+	showRedaction bool
+	runCustomer   string
 )
 
 var promptRunCmd = &cobra.Command{
@@ -36,27 +47,50 @@ Examples:
   # With file input
   now-sc prompt run sales-discovery --file inbox/notes/meeting.txt
 
+  # With globs, directories, URLs or stdin ("-") as context
+  now-sc prompt run sales-discovery --file '00_Inbox/calls/**/*.vtt'
+  now-sc prompt run sales-discovery --file 00_Inbox/emails --exclude '*.eml'
+  now-sc prompt run sales-discovery --file https://example.com/rfp.txt
+  cat notes.txt | now-sc prompt run sales-discovery --file - --file 00_Inbox/notes
+
+  # Inject a customer's profile and save into that customer's folder
+  now-sc prompt run sales-discovery --customer "Acme Corp"
+
   # Interactive input
   now-sc prompt run sales-discovery
 
   # Auto-discover files from inbox
   now-sc prompt run sales-discovery --discover
+
+  # Only offer inbox files that have not been processed yet
+  now-sc prompt run sales-discovery --discover --only-new
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: runPromptRun,
 }
 
 func init() {
-	promptRunCmd.Flags().StringSliceVarP(&inputFiles, "file", "f", []string{}, "Input file(s) to include as context")
+	promptRunCmd.Flags().StringSliceVarP(&inputFiles, "file", "f", []string{}, "Input file(s), glob(s), directories, URLs or - for stdin to include as context")
+	promptRunCmd.Flags().StringSliceVar(&includeFiles, "include", []string{}, "Only include files matching these patterns when expanding directories and globs")
+	promptRunCmd.Flags().StringSliceVar(&excludeFiles, "exclude", []string{}, "Skip files matching these patterns when expanding directories and globs")
+	promptRunCmd.Flags().StringVar(&maxSize, "max-size", "2MB", "Maximum combined size of all context inputs")
+	promptRunCmd.Flags().BoolVar(&redactInput, "redact", true, "Replace emails, phone numbers, IPs, secrets and configured terms with placeholders before sending")
+	promptRunCmd.Flags().BoolVar(&showRedaction, "show-redactions", false, "Preview redactions and confirm before sending")
+	promptRunCmd.Flags().StringVar(&runCustomer, "customer", "", "Inject this customer's profile into the prompt and offer their folder as the save location (default: the project's customer, which only fills placeholders)")
+	promptRunCmd.Flags().StringVar(&modelName, "model", "", "OpenRouter model to use (default: "+openrouter.DefaultModel+")")
 	promptRunCmd.Flags().BoolVar(&useClaudeCode, "claude", true, "Use Claude Code instead of OpenRouter (default: true)")
 	promptRunCmd.Flags().BoolVar(&discoverFiles, "discover", false, "Auto-discover and select files from inbox")
 	promptRunCmd.Flags().BoolVar(&saveOutput, "save", true, "Prompt to save output (default: true)")
 	promptRunCmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file path (skips save prompt)")
+	promptRunCmd.Flags().BoolVar(&onlyNewFiles, "only-new", false, "With --discover, only offer inbox files that are new or changed since they were last processed")
 }
 
 func runPromptRun(cmd *cobra.Command, args []string) error {
 	promptName := args[0]
-	projectRoot := "."
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
 
 	// Find the prompt
 	prompt, err := FindPrompt(projectRoot, promptName)
@@ -73,21 +107,54 @@ func runPromptRun(cmd *cobra.Command, args []string) error {
 	}
 
 	// Read prompt content
-	promptContent, err := os.ReadFile(prompt.Path)
+	templateContent, err := os.ReadFile(prompt.Path)
 	if err != nil {
 		return fmt.Errorf("failed to read prompt file: %w", err)
 	}
+	promptContent := string(templateContent)
 
 	color.Cyan("Using prompt: %s", prompt.Name)
+
+	// Fill the template from the customer's profile
+	customerDir := ""
+	if runCustomer != "" {
+		layout, err := project.LoadProjectLayout(projectRoot)
+		if err != nil {
+			return err
+		}
+		profile, err := project.LoadCustomer(projectRoot, layout, runCustomer)
+		if err != nil {
+			return err
+		}
+		promptContent = profile.ApplyToTemplate(promptContent)
+		customerDir = filepath.Join(layout.Path(project.RoleCustomers), profile.Name)
+		color.Cyan("Using customer profile: %s", profile.Name)
+	} else {
+		promptContent, customerDir, err = applyProjectCustomer(projectRoot, promptContent)
+		if err != nil {
+			return err
+		}
+	}
 	fmt.Println()
 
 	// Get user input
 	var userInput string
 	var fileContext string
 
-	// Check if stdin has data (piped input)
+	maxBytes, err := ParseSize(maxSize)
+	if err != nil {
+		return err
+	}
+
+	// Expand globs, directories, URLs and stdin in --file
+	inputFiles, err = ExpandInputs(inputFiles, InputOptions{Include: includeFiles, Exclude: excludeFiles})
+	if err != nil {
+		return err
+	}
+
+	// Check if stdin has data (piped input), unless it is used as a context file
 	stat, _ := os.Stdin.Stat()
-	if (stat.Mode() & os.ModeCharDevice) == 0 {
+	if (stat.Mode()&os.ModeCharDevice) == 0 && !containsString(inputFiles, StdinInput) {
 		// Reading from pipe
 		data, err := io.ReadAll(os.Stdin)
 		if err != nil {
@@ -100,7 +167,12 @@ func runPromptRun(cmd *cobra.Command, args []string) error {
 
 	// Handle file discovery
 	if discoverFiles {
-		selectedFiles, err := discoverAndSelectFiles(projectRoot)
+		// Without a terminal nothing is chosen on the user's behalf, as that
+		// would send the whole inbox to the provider
+		if !isInteractive() {
+			return missingValue("a selection of inbox files", "--file")
+		}
+		selectedFiles, err := discoverAndSelectFiles(projectRoot, onlyNewFiles)
 		if err != nil {
 			color.Yellow("Warning: %v", err)
 		} else if len(selectedFiles) > 0 {
@@ -108,18 +180,32 @@ func runPromptRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Images are sent as separate content parts, everything else as text
+	textFiles, imageFiles := splitImageInputs(inputFiles)
+	images, err := loadImages(projectRoot, imageFiles)
+	if err != nil {
+		return err
+	}
+
 	// Read file contexts
-	if len(inputFiles) > 0 {
-		fileContext, err = FormatFileContext(inputFiles)
+	if len(textFiles) > 0 {
+		fileContext, err = FormatFileContext(projectRoot, textFiles, maxBytes)
 		if err != nil {
 			return fmt.Errorf("failed to read context files: %w", err)
 		}
-		color.Green("✓ Loaded %d context file(s)", len(inputFiles))
+		color.Green("✓ Loaded %d context file(s)", len(textFiles))
+		fmt.Println()
+	}
+	if len(images) > 0 {
+		color.Green("✓ Loaded %d image(s)", len(images))
 		fmt.Println()
 	}
 
 	// If no input yet, prompt for it
-	if userInput == "" && fileContext == "" {
+	if userInput == "" && fileContext == "" && len(images) == 0 {
+		if !isInteractive() {
+			return fmt.Errorf("no input for the prompt; pipe it to stdin or pass --file")
+		}
 		promptInput := promptui.Prompt{
 			Label: "Enter your input for this prompt",
 		}
@@ -142,25 +228,41 @@ func runPromptRun(cmd *cobra.Command, args []string) error {
 	color.Cyan("Executing prompt...")
 	fmt.Println()
 
-	var result string
-	if useClaudeCode {
-		// Check if Claude Code is available
-		if !claude.IsAvailable() {
-			color.Red("Error: Claude Code is not installed or not in PATH")
-			color.Yellow("Please install Claude Code or use --claude=false to use OpenRouter")
-			return fmt.Errorf("Claude Code not available")
+	// Redact sensitive values; the placeholders are restored locally
+	sentInput := fullInput
+	redactor, err := loadRedactor(projectRoot)
+	if err != nil {
+		return err
+	}
+	if redactInput {
+		promptContent = redactor.Redact(promptContent)
+		sentInput = redactor.Redact(fullInput)
+		if n := len(redactor.Redactions()); n > 0 {
+			color.Green("✓ Redacted %d sensitive value(s)", n)
 		}
-
-		client := claude.NewClient()
-		result, err = client.ExecutePrompt(string(promptContent), fullInput)
-		if err != nil {
-			return fmt.Errorf("failed to execute prompt with Claude Code: %w", err)
+	}
+	if showRedaction {
+		printRedactions(redactor, sentInput)
+		if ok, err := confirm("Send this input to the AI provider"); err != nil {
+			return err
+		} else if !ok {
+			color.Yellow("Prompt not sent.")
+			return nil
 		}
-	} else {
-		// Fallback to OpenRouter (existing implementation)
-		return fmt.Errorf("OpenRouter integration not yet implemented for run command")
 	}
 
+	provider, providerName, err := newProvider(useClaudeCode, modelName)
+	if err != nil {
+		return err
+	}
+
+	color.Cyan("Using %s...", providerName)
+	result, err := executeWithImages(provider, providerName, promptContent, sentInput, images)
+	if err != nil {
+		return fmt.Errorf("failed to execute prompt with %s: %w", providerName, err)
+	}
+	result = redactor.Restore(result)
+
 	// Display response
 	fmt.Println()
 	color.Cyan("Response:")
@@ -173,37 +275,156 @@ func runPromptRun(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Handle output saving
+	var savedPath string
 	if outputPath != "" {
 		// Direct output to specified path
-		return savePromptOutput(projectRoot, prompt.Name, fullInput, result, outputPath)
+		savedPath, err = savePromptOutput(projectRoot, prompt.Name, fullInput, result, outputPath)
+		if err != nil {
+			return err
+		}
+	} else if saveOutput && !isInteractive() && !assumeYes {
+		color.Yellow("Output not saved: pass --output, or --yes to save to the default location.")
+	} else if saveOutput {
+		// Ask if user wants to save
+		save := assumeYes
+		if !save {
+			promptSave := promptui.Prompt{
+				Label:     "Would you like to save this output",
+				IsConfirm: true,
+				Default:   "y",
+			}
+			_, err := promptSave.Run()
+			save = err == nil
+		}
+
+		if save {
+			savedPath, err = savePromptOutputInteractive(projectRoot, prompt.Name, fullInput, result, customerDir)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
-	if saveOutput {
-		// Ask if user wants to save
-		promptSave := promptui.Prompt{
-			Label:     "Would you like to save this output",
-			IsConfirm: true,
-			Default:   "y",
+	return recordInboxRuns(projectRoot, inputFiles, prompt.FileName, savedPath)
+}
+
+// splitImageInputs separates image inputs from text inputs, keeping order
+func splitImageInputs(inputs []string) (text, images []string) {
+	for _, input := range inputs {
+		if media.IsImage(input) {
+			images = append(images, input)
+		} else {
+			text = append(text, input)
 		}
+	}
+	return text, images
+}
 
-		_, err = promptSave.Run()
+// loadImages reads and downscales image inputs
+func loadImages(projectRoot string, inputs []string) ([]media.Image, error) {
+	var images []media.Image
+	for _, input := range inputs {
+		data, err := readInput(input, media.MaxImageSize)
 		if err != nil {
-			// User declined to save
-			return nil
+			return nil, err
 		}
+		image, err := media.Prepare(inputLabel(projectRoot, input), data)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, *image)
+	}
+	return images, nil
+}
 
-		return savePromptOutputInteractive(projectRoot, prompt.Name, fullInput, result)
+// applyProjectCustomer uses the project's own customer when --customer is
+// not given: their folder is offered as the save location and their profile
+// fills the template's customer placeholders. Nothing is appended to the
+// prompt, and a customer whose folder or profile is gone is skipped.
+func applyProjectCustomer(projectRoot, promptContent string) (string, string, error) {
+	manifest, err := project.LoadManifest(projectRoot)
+	if err != nil || manifest.Customer == "" {
+		return promptContent, "", err
+	}
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return promptContent, "", err
+	}
+	profile, err := project.LoadCustomer(projectRoot, layout, manifest.Customer)
+	if err != nil {
+		return promptContent, "", nil
 	}
+	customerDir := filepath.Join(layout.Path(project.RoleCustomers), profile.Name)
 
-	return nil
+	profilePath := filepath.Join(project.CustomerDir(projectRoot, layout, profile.Name), project.CustomerProfileFile)
+	if strings.Contains(promptContent, "{{customer") && fileExists(profilePath) {
+		promptContent = profile.ApplyToTemplate(promptContent)
+		color.Cyan("Using customer profile: %s", profile.Name)
+	}
+	return promptContent, customerDir, nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// recordInboxRuns marks the inbox files used as context as processed
+func recordInboxRuns(projectRoot string, files []string, template, savedPath string) error {
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return err
+	}
+	inboxPrefix := filepath.ToSlash(layout.Path(project.RoleInbox)) + "/"
+
+	state, err := inbox.LoadState(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	if savedPath != "" {
+		savedPath = inputLabel(projectRoot, savedPath)
+	}
+
+	recorded := 0
+	for _, file := range files {
+		relPath := inputLabel(projectRoot, file)
+		if !strings.HasPrefix(relPath, inboxPrefix) {
+			continue
+		}
+		if err := state.RecordRun(projectRoot, relPath, template, savedPath); err != nil {
+			color.Yellow("Warning: could not record processing state: %v", err)
+			continue
+		}
+		recorded++
+	}
+
+	if recorded == 0 {
+		return nil
+	}
+	return state.Save(projectRoot)
 }
 
-func discoverAndSelectFiles(projectRoot string) ([]string, error) {
+func discoverAndSelectFiles(projectRoot string, onlyNew bool) ([]string, error) {
 	files, err := DiscoverFiles(projectRoot)
 	if err != nil {
 		return nil, err
 	}
 
+	if onlyNew {
+		files, err = filterUnprocessed(projectRoot, files)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no new or changed files found in inbox")
+		}
+	}
+
 	if len(files) == 0 {
 		return nil, fmt.Errorf("no files found in inbox")
 	}
@@ -234,7 +455,27 @@ func discoverAndSelectFiles(projectRoot string) ([]string, error) {
 	return []string{files[idx].Path}, nil
 }
 
-func savePromptOutput(projectRoot, promptName, input, response, outputPath string) error {
+// filterUnprocessed keeps the files that are new or changed since they were last processed
+func filterUnprocessed(projectRoot string, files []FileInfo) ([]FileInfo, error) {
+	state, err := inbox.LoadState(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var unprocessed []FileInfo
+	for _, file := range files {
+		status, err := state.Status(projectRoot, file.RelativePath)
+		if err != nil {
+			return nil, err
+		}
+		if status != inbox.StatusProcessed {
+			unprocessed = append(unprocessed, file)
+		}
+	}
+	return unprocessed, nil
+}
+
+func savePromptOutput(projectRoot, promptName, input, response, outputPath string) (string, error) {
 	// Create output content
 	outputContent := fmt.Sprintf(`# %s
 
@@ -257,27 +498,58 @@ func savePromptOutput(projectRoot, promptName, input, response, outputPath strin
 	// Ensure directory exists
 	dir := filepath.Dir(outputPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+		return "", fmt.Errorf("failed to create directory: %w", err)
 	}
 
 	// Write file
 	if err := os.WriteFile(outputPath, []byte(outputContent), 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+		return "", fmt.Errorf("failed to write file: %w", err)
 	}
 
 	color.Green("✓ Output saved to: %s", outputPath)
-	return nil
+	return outputPath, nil
 }
 
-func savePromptOutputInteractive(projectRoot, promptName, input, response string) error {
-	// Select output location
-	locations := []string{
-		"Project Overview (99_Assets/Project_Overview)",
-		"Communications (99_Assets/Communications)",
-		"POC Documents (99_Assets/POC_Documents)",
-		"Notes (00_Inbox/notes)",
-		"Other (specify)",
+// inInbox reports whether the project-relative path is in an inbox folder
+func inInbox(layout *project.Layout, path string) bool {
+	for _, role := range []string{project.RoleInbox, project.RoleInboxUnsorted} {
+		dir := layout.Path(role)
+		if dir == "" {
+			continue
+		}
+		if rel, err := filepath.Rel(dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
 	}
+	return false
+}
+
+// selectSaveLocation asks where to save output, offering the customer's
+// folder (if any), the layout's save targets and a custom path relative to
+// the project root
+func selectSaveLocation(layout *project.Layout, customerDir string) (string, error) {
+	targets := layout.SaveTargets()
+	if customerDir != "" {
+		customer := project.SaveTarget{Label: "Customer " + filepath.Base(customerDir), Path: customerDir}
+		targets = append([]project.SaveTarget{customer}, targets...)
+	}
+
+	// Without a terminal the first location outside the inbox is used, so
+	// output is never picked up again as raw material
+	if !isInteractive() {
+		for _, target := range targets {
+			if !inInbox(layout, target.Path) {
+				return target.Path, nil
+			}
+		}
+		return "", missingValue("an output location", "--output")
+	}
+
+	locations := make([]string, 0, len(targets)+1)
+	for _, target := range targets {
+		locations = append(locations, fmt.Sprintf("%s (%s)", target.Label, filepath.ToSlash(target.Path)))
+	}
+	locations = append(locations, "Other (specify)")
 
 	locationSelect := promptui.Select{
 		Label: "Where would you like to save the output?",
@@ -286,33 +558,45 @@ func savePromptOutputInteractive(projectRoot, promptName, input, response string
 
 	locIdx, _, err := locationSelect.Run()
 	if err != nil {
-		return nil
+		return "", err
 	}
 
-	var savePath string
-	switch locIdx {
-	case 0:
-		savePath = "99_Assets/Project_Overview"
-	case 1:
-		savePath = "99_Assets/Communications"
-	case 2:
-		savePath = "99_Assets/POC_Documents"
-	case 3:
-		savePath = "00_Inbox/notes"
-	case 4:
-		promptCustom := promptui.Prompt{
-			Label:   "Enter the path (relative to project root)",
-			Default: "99_Assets",
-		}
-		customPath, err := promptCustom.Run()
-		if err != nil {
-			return nil
+	if locIdx < len(targets) {
+		return targets[locIdx].Path, nil
+	}
+
+	defaultPath := ""
+	if len(targets) > 0 {
+		defaultPath = filepath.Dir(targets[0].Path)
+	}
+	promptCustom := promptui.Prompt{
+		Label:   "Enter the path (relative to project root)",
+		Default: defaultPath,
+	}
+	return promptCustom.Run()
+}
+
+func savePromptOutputInteractive(projectRoot, promptName, input, response, customerDir string) (string, error) {
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return "", err
+	}
+
+	// Select output location
+	savePath, err := selectSaveLocation(layout, customerDir)
+	if err != nil {
+		if !isInteractive() {
+			return "", err
 		}
-		savePath = customPath
+		return "", nil
 	}
 
 	// Get filename
 	defaultFilename := strings.ReplaceAll(promptName, " ", "_") + "_" + time.Now().Format("2006-01-02")
+	if !isInteractive() {
+		fullPath := filepath.Join(projectRoot, savePath, defaultFilename+".md")
+		return savePromptOutput(projectRoot, promptName, input, response, fullPath)
+	}
 	promptFilename := promptui.Prompt{
 		Label:   "Enter filename (without extension)",
 		Default: defaultFilename,
@@ -326,7 +610,7 @@ func savePromptOutputInteractive(projectRoot, promptName, input, response string
 
 	filename, err := promptFilename.Run()
 	if err != nil {
-		return nil
+		return "", nil
 	}
 
 	fullPath := filepath.Join(projectRoot, savePath, filename+".md")
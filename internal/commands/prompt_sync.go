@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var syncDryRun bool
+
+var promptSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Update prompt templates from their sources, keeping local edits",
+	Long: `Fetches the latest prompts from the project's prompt sources and merges them
+with local edits. Each prompt is compared with the upstream version it was last
+synced from (kept in ` + prompts.BaseDir + `):
+
+  new              - added upstream, written
+  updated          - changed upstream only, replaced
+  merged           - changed on both sides in different places, merged
+  conflict         - changed on both sides in the same place, written with conflict markers
+  locally modified - changed locally only, kept
+  removed          - removed upstream and not changed locally, deleted
+  deleted locally  - removed locally, stays removed
+
+Examples:
+  now-sc prompts sync --dry-run
+  now-sc prompts sync`,
+	RunE: runPromptSync,
+}
+
+func init() {
+	promptSyncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show what would change without writing anything")
+}
+
+func runPromptSync(cmd *cobra.Command, args []string) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return err
+	}
+	manifest, err := project.LoadManifest(projectRoot)
+	if err != nil {
+		return err
+	}
+	sources, err := projectPromptSources(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	// Follow each source's ref rather than the commit it is pinned to
+	latest := make(project.PromptSources, len(sources))
+	for i, source := range sources {
+		source.Commit = ""
+		latest[i] = source
+	}
+
+	upstreamDir, err := os.MkdirTemp("", "now-sc-prompts-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(upstreamDir)
+
+	fmt.Println(color.CyanString("Fetching prompts from %s...", latest))
+	fetched, err := fetchPrompts(latest, upstreamDir)
+	if err != nil {
+		return err
+	}
+
+	promptsDir := filepath.Join(projectRoot, layout.Path(project.RolePrompts))
+	changes, err := prompts.PlanSync(projectRoot, promptsDir, upstreamDir)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(projectRoot, prompts.BaseDir)); os.IsNotExist(err) {
+		color.Yellow("No sync base recorded yet: prompts that differ from upstream are reported as conflicts.")
+	}
+
+	fmt.Println()
+	counts := map[prompts.Status]int{}
+	for _, change := range changes {
+		counts[change.Status]++
+		if change.Status == prompts.StatusUnchanged {
+			continue
+		}
+		line := fmt.Sprintf("  %-16s %s", change.Status, change.Path)
+		switch change.Status {
+		case prompts.StatusConflict:
+			color.Red(line)
+		case prompts.StatusLocallyModified, prompts.StatusDeletedLocally:
+			color.Yellow(line)
+		default:
+			color.Green(line)
+		}
+	}
+	if counts[prompts.StatusUnchanged] == len(changes) {
+		color.Green("✓ Prompts are up to date")
+	} else if counts[prompts.StatusUnchanged] > 0 {
+		fmt.Printf("  %d prompt(s) unchanged\n", counts[prompts.StatusUnchanged])
+	}
+
+	if syncDryRun {
+		fmt.Println("\nDry run: nothing was changed.")
+		return nil
+	}
+
+	if err := prompts.ApplySync(projectRoot, promptsDir, upstreamDir, changes); err != nil {
+		return err
+	}
+	manifest.Prompts, manifest.PromptSnapshot = fetched.Sources, fetched.Snapshot
+	if err := manifest.Save(projectRoot); err != nil {
+		return err
+	}
+	fmt.Println()
+	color.Green("✓ Synced prompts from %s", fetched.Sources)
+
+	if conflicts := counts[prompts.StatusConflict]; conflicts > 0 {
+		color.Yellow("Resolve the conflict markers (<<<<<<< local ... >>>>>>> upstream) in the files above.")
+		return fmt.Errorf("%d prompt(s) have conflicts", conflicts)
+	}
+	return nil
+}
@@ -3,39 +3,58 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/inbox"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
 )
 
 // PromptInfo contains information about a prompt template
 type PromptInfo struct {
 	Name        string // Display name (without .md extension)
+	Category    string // Subfolder of the prompts folder, empty at its top level
 	FileName    string // Actual filename
 	Path        string // Full path to file
 	Description string // First line of the prompt (if available)
 }
 
-// ListPrompts returns all available prompt templates
+// DisplayName is the prompt's name prefixed with its category
+func (p PromptInfo) DisplayName() string {
+	if p.Category == "" {
+		return p.Name
+	}
+	return p.Category + "/" + p.Name
+}
+
+// ListPrompts returns all available prompt templates, including those in
+// category subfolders
 func ListPrompts(projectRoot string) ([]PromptInfo, error) {
-	promptsPath := filepath.Join(projectRoot, "10_PromptTemplates")
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	promptsPath := filepath.Join(projectRoot, layout.Path(project.RolePrompts))
 
 	if _, err := os.Stat(promptsPath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("prompt templates directory not found at: %s", promptsPath)
 	}
 
-	entries, err := os.ReadDir(promptsPath)
+	files, err := project.ListFiles(promptsPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read prompts directory: %w", err)
 	}
 
 	var prompts []PromptInfo
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+	for _, file := range files {
+		if !strings.HasSuffix(file, ".md") {
 			continue
 		}
 
-		fullPath := filepath.Join(promptsPath, entry.Name())
-		name := strings.TrimSuffix(entry.Name(), ".md")
+		fullPath := filepath.Join(promptsPath, filepath.FromSlash(file))
+		category, fileName := path.Split(file)
+		name := strings.TrimSuffix(fileName, ".md")
 		name = strings.ReplaceAll(name, "_", " ")
 
 		// Try to read first line for description
@@ -52,7 +71,8 @@ func ListPrompts(projectRoot string) ([]PromptInfo, error) {
 
 		prompts = append(prompts, PromptInfo{
 			Name:        name,
-			FileName:    entry.Name(),
+			Category:    strings.TrimSuffix(category, "/"),
+			FileName:    fileName,
 			Path:        fullPath,
 			Description: description,
 		})
@@ -83,6 +103,9 @@ func FindPrompt(projectRoot, promptName string) (*PromptInfo, error) {
 		if strings.ToLower(strings.TrimSuffix(prompt.FileName, ".md")) == searchTerm {
 			return &prompt, nil
 		}
+		if prompt.Category != "" && strings.ToLower(prompt.Category+"/"+strings.TrimSuffix(prompt.FileName, ".md")) == searchTerm {
+			return &prompt, nil
+		}
 	}
 
 	// Partial match
@@ -104,9 +127,14 @@ type FileInfo struct {
 	ModTime      string // Last modified time
 }
 
-// DiscoverFiles scans the inbox folders for files
+// DiscoverFiles scans the inbox folders for files, skipping the archive
 func DiscoverFiles(projectRoot string) ([]FileInfo, error) {
-	inboxPath := filepath.Join(projectRoot, "00_Inbox")
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	inboxDir := layout.Path(project.RoleInbox)
+	inboxPath := filepath.Join(projectRoot, inboxDir)
 
 	if _, err := os.Stat(inboxPath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("inbox directory not found at: %s", inboxPath)
@@ -114,22 +142,25 @@ func DiscoverFiles(projectRoot string) ([]FileInfo, error) {
 
 	var files []FileInfo
 
-	err := filepath.Walk(inboxPath, func(path string, info os.FileInfo, err error) error {
+	err = filepath.Walk(inboxPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Skip directories
-		if info.IsDir() {
-			return nil
-		}
-
 		// Get relative path
 		relPath, err := filepath.Rel(projectRoot, path)
 		if err != nil {
 			relPath = path
 		}
 
+		// Skip directories and archived files
+		if info.IsDir() {
+			if inbox.IsArchived(inboxDir, relPath) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
 		files = append(files, FileInfo{
 			Path:         path,
 			RelativePath: relPath,
@@ -157,20 +188,28 @@ func ReadFileContent(filePath string) (string, error) {
 	return string(content), nil
 }
 
-// FormatFileContext formats file contents for inclusion in a prompt
-func FormatFileContext(files []string) (string, error) {
+// FormatFileContext formats the content of expanded inputs for inclusion in
+// a prompt, labelling each by its path relative to the project root. It fails
+// if the combined size exceeds maxBytes.
+func FormatFileContext(projectRoot string, files []string, maxBytes int64) (string, error) {
 	var builder strings.Builder
+	var total int64
 
 	builder.WriteString("Context Files:\n\n")
 
 	for _, filePath := range files {
-		content, err := ReadFileContent(filePath)
+		content, err := readInput(filePath, maxBytes-total)
 		if err != nil {
 			return "", err
 		}
 
-		builder.WriteString(fmt.Sprintf("=== File: %s ===\n\n", filepath.Base(filePath)))
-		builder.WriteString(content)
+		total += int64(len(content))
+		if total > maxBytes {
+			return "", fmt.Errorf("context exceeds the %s size cap at %s (raise it with --max-size)", formatSize(maxBytes), inputLabel(projectRoot, filePath))
+		}
+
+		builder.WriteString(fmt.Sprintf("=== File: %s ===\n\n", inputLabel(projectRoot, filePath)))
+		builder.Write(content)
 		builder.WriteString("\n\n")
 	}
 
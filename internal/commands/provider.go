@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/claude"
+	"github.com/Now-AI-Foundry/Now-SC/internal/media"
+	"github.com/Now-AI-Foundry/Now-SC/internal/openrouter"
+	"github.com/fatih/color"
+)
+
+// promptExecutor is implemented by every AI provider client
+type promptExecutor interface {
+	ExecutePrompt(promptContent, userInput string) (string, error)
+}
+
+// imagePromptExecutor is implemented by providers that can send images to
+// vision-capable models
+type imagePromptExecutor interface {
+	ExecutePromptWithImages(promptContent, userInput string, images []media.Image) (string, error)
+}
+
+// newProvider returns the AI provider selected by the --claude flag along
+// with a display name for progress messages. model selects the OpenRouter
+// model and is ignored for Claude Code; empty means the default model.
+func newProvider(useClaude bool, model string) (promptExecutor, string, error) {
+	if useClaude {
+		if !claude.IsAvailable() {
+			color.Red("Error: Claude Code is not installed or not in PATH")
+			color.Yellow("Please install Claude Code or use --claude=false to use OpenRouter")
+			return nil, "", fmt.Errorf("Claude Code not available")
+		}
+		return claude.NewClient(), "Claude Code", nil
+	}
+
+	apiKey := secret("openrouter.api_key")
+	if apiKey == "" {
+		color.Red("Error: no OpenRouter API key configured")
+		color.Yellow("Please set OPENROUTER_API_KEY (or run \"now-sc auth login openrouter\") or use --claude to use Claude Code")
+		return nil, "", fmt.Errorf("no AI provider configured")
+	}
+	client := openrouter.NewClient(apiKey)
+	client.SetModel(model)
+	return client, "OpenRouter", nil
+}
+
+// executeWithImages runs a prompt, attaching images when there are any. It
+// fails with a clear message when the provider cannot accept images.
+func executeWithImages(provider promptExecutor, providerName, promptContent, userInput string, images []media.Image) (string, error) {
+	if len(images) == 0 {
+		return provider.ExecutePrompt(promptContent, userInput)
+	}
+
+	vision, ok := provider.(imagePromptExecutor)
+	if !ok {
+		return "", fmt.Errorf("%s does not accept image inputs; use --claude=false with a vision-capable --model", providerName)
+	}
+	return vision.ExecutePromptWithImages(promptContent, userInput, images)
+}
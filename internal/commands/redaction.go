@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/redact"
+	"github.com/fatih/color"
+)
+
+// RedactionConfigFile holds project-wide redaction rules, relative to the project root
+const RedactionConfigFile = ".now-sc/redaction.json"
+
+// loadRedactor builds a redactor from the project config and every
+// customer's redaction.json in the customers folder
+func loadRedactor(projectRoot string) (*redact.Redactor, error) {
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{filepath.Join(projectRoot, RedactionConfigFile)}
+	customerConfigs, err := filepath.Glob(filepath.Join(projectRoot, layout.Path(project.RoleCustomers), "*", "redaction.json"))
+	if err != nil {
+		return nil, err
+	}
+	paths = append(paths, customerConfigs...)
+
+	cfg, err := redact.LoadConfig(paths...)
+	if err != nil {
+		return nil, err
+	}
+	return redact.New(cfg)
+}
+
+// printRedactions shows what will be replaced before anything is sent
+func printRedactions(redactor *redact.Redactor, redacted string) {
+	redactions := redactor.Redactions()
+
+	fmt.Println()
+	color.Cyan("Redactions (%d):", len(redactions))
+	if len(redactions) == 0 {
+		fmt.Println("  (nothing detected)")
+	}
+	for _, r := range redactions {
+		fmt.Printf("  %-14s %s\n", r.Placeholder, redact.Mask(r))
+	}
+
+	fmt.Println()
+	color.Cyan("Redacted input:")
+	fmt.Println("─────────────────────────────────────────")
+	fmt.Println(redacted)
+	fmt.Println("─────────────────────────────────────────")
+	fmt.Println()
+}
+
+// redactingExecutor redacts the prompt and input before they reach the
+// provider and restores placeholders in the response
+type redactingExecutor struct {
+	provider promptExecutor
+	redactor *redact.Redactor
+}
+
+func (e redactingExecutor) ExecutePrompt(promptContent, userInput string) (string, error) {
+	result, err := e.provider.ExecutePrompt(e.redactor.Redact(promptContent), e.redactor.Redact(userInput))
+	if err != nil {
+		return "", err
+	}
+	return e.redactor.Restore(result), nil
+}
@@ -9,7 +9,8 @@ var rootCmd = &cobra.Command{
 	Short: "CLI tool for bootstrapping presales projects for solution consultants",
 	Long: `Now-SC is a CLI tool that helps solution consultants bootstrap and manage
 presales projects with structured directories, prompt templates, and AI-powered workflows.`,
-	Version: "1.0.0",
+	Version:           "1.0.0",
+	PersistentPreRunE: loadSettings,
 }
 
 // Execute runs the root command
@@ -18,7 +19,19 @@ func Execute() error {
 }
 
 func init() {
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "Never prompt; fail when a required value is missing (automatic when stdin is not a terminal)")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Answer yes to every confirmation")
+
 	// Add subcommands
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(promptCmd)
+	rootCmd.AddCommand(inboxCmd)
+	rootCmd.AddCommand(customerCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(upgradeCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(authCmd)
+	rootCmd.AddCommand(templatesCmd)
+	rootCmd.AddCommand(commsCmd)
+	rootCmd.AddCommand(versionCmd)
 }
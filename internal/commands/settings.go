@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/config"
+	"github.com/Now-AI-Foundry/Now-SC/internal/credentials"
+	"github.com/Now-AI-Foundry/Now-SC/internal/github"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// passphraseEnv supplies the credential file passphrase without a prompt
+const passphraseEnv = "NOW_SC_CREDENTIALS_PASSPHRASE"
+
+var (
+	// settings is the layered configuration of the running command
+	settings *config.Config
+
+	// credentialStore is opened on first use, so commands that need no
+	// secret never ask for a passphrase
+	credentialStore credentials.Store
+)
+
+// loadSettings resolves the configuration for cmd. Flags given on the command
+// line override it; flags that were not given take their value from it.
+func loadSettings(cmd *cobra.Command, args []string) error {
+	projectRoot, err := project.FindRoot(".")
+	if err != nil {
+		projectRoot = ""
+	}
+
+	cfg, err := config.Load(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	for _, key := range config.Keys {
+		if key.Flag == "" {
+			continue
+		}
+		flag := cmd.Flags().Lookup(key.Flag)
+		if flag == nil {
+			continue
+		}
+		if flag.Changed {
+			cfg.Set(key.Name, flag.Value.String(), config.SourceFlag, "--"+key.Flag)
+			continue
+		}
+		value := cfg.Lookup(key.Name)
+		if value.Source == config.SourceDefault {
+			continue
+		}
+		if err := flag.Value.Set(value.Value); err != nil {
+			return fmt.Errorf("invalid %s from %s: %w", key.Name, value.Origin, err)
+		}
+	}
+
+	// --claude is the boolean form of the provider setting
+	if flag := cmd.Flags().Lookup("claude"); flag != nil {
+		if flag.Changed {
+			provider := project.ProviderOpenRouter
+			if flag.Value.String() == "true" {
+				provider = project.ProviderClaude
+			}
+			cfg.Set("provider", provider, config.SourceFlag, "--claude")
+		} else {
+			flag.Value.Set(strconv.FormatBool(cfg.Get("provider") != project.ProviderOpenRouter))
+		}
+	}
+
+	settings = cfg
+	return nil
+}
+
+// secret returns a secret setting. When no configuration layer sets it, the
+// credential stored by "now-sc auth login" is used.
+func secret(name string) string {
+	if value := settings.Lookup(name); value.Source != config.SourceDefault {
+		return value.Value
+	}
+	provider, ok := credentials.ProviderForSetting(name)
+	if !ok {
+		return ""
+	}
+
+	store, err := openCredentialStore()
+	if err != nil {
+		color.Yellow("Warning: %v", err)
+		return ""
+	}
+	value, err := store.Get(provider.Name)
+	if err != nil {
+		if !errors.Is(err, credentials.ErrNotFound) {
+			color.Yellow("Warning: failed to read the stored %s: %v", provider.Label, err)
+		}
+		return ""
+	}
+	settings.Set(name, value, config.SourceCredentials, store.Name())
+	return value
+}
+
+// githubClient returns a client for the configured GitHub host and
+// organization, authenticated with the configured token
+func githubClient() *github.Client {
+	client := github.NewClient(secret("github.token"))
+	if apiURL, err := github.ParseAPIURL(settings.Get("github.api_url")); err == nil {
+		client.APIURL = apiURL
+	}
+	if uploadURL, err := github.ParseUploadURL(settings.Get("github.upload_url")); err == nil {
+		client.UploadURL = uploadURL
+	}
+	client.Org = settings.Get("github.org")
+	client.Visibility = settings.Get("github.visibility")
+	return client
+}
+
+// openCredentialStore opens the configured credential store once
+func openCredentialStore() (credentials.Store, error) {
+	if credentialStore != nil {
+		return credentialStore, nil
+	}
+	store, err := credentials.Open(settings.Get("credentials.store"), askPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	credentialStore = store
+	return store, nil
+}
+
+// askPassphrase reads the credential file passphrase from the environment
+// or, in a terminal, asks for it. A new passphrase is asked for twice.
+func askPassphrase(create bool) (string, error) {
+	if passphrase := os.Getenv(passphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	if !isInteractive() {
+		return "", fmt.Errorf("the credential file is encrypted; set %s to unlock it", passphraseEnv)
+	}
+
+	label := "Credential file passphrase"
+	if create {
+		label = "New passphrase for the credential file"
+	}
+	prompt := promptui.Prompt{Label: label, Mask: '*'}
+	passphrase, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("passphrase input cancelled")
+	}
+
+	if create {
+		confirmPrompt := promptui.Prompt{Label: "Confirm passphrase", Mask: '*'}
+		again, err := confirmPrompt.Run()
+		if err != nil {
+			return "", fmt.Errorf("passphrase input cancelled")
+		}
+		if again != passphrase {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,242 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var templatesForce bool
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Work with communication templates",
+	Long: `Communication templates are the files in the ` + prompts.TemplatesPath + ` folder of each prompt
+source, such as status report emails. Subfolders are kept as categories.
+
+Subcommands:
+  list - List the templates the prompt sources offer
+  pull - Copy templates into the project`,
+	Aliases: []string{"template"},
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the templates the prompt sources offer",
+	Long: `Lists the communication templates of the current project's prompt sources, or
+of the configured ones outside a project, and whether each is in the project.`,
+	RunE: runTemplatesList,
+}
+
+var templatesPullCmd = &cobra.Command{
+	Use:   "pull [template...]",
+	Short: "Copy templates into the project",
+	Long: `Copies the named templates, or all of them, into the project's communication
+templates folder. Templates are named by path, category/name or name. Templates
+that were edited locally are kept unless --force is given.
+
+Examples:
+  now-sc templates pull
+  now-sc templates pull status/weekly_update --force`,
+	RunE: runTemplatesPull,
+}
+
+func init() {
+	templatesPullCmd.Flags().BoolVar(&templatesForce, "force", false, "Overwrite templates that were edited locally")
+
+	// Add subcommands
+	templatesCmd.AddCommand(templatesListCmd)
+	templatesCmd.AddCommand(templatesPullCmd)
+}
+
+func runTemplatesList(cmd *cobra.Command, args []string) error {
+	sources, err := project.ParsePromptSources(settings.Get("prompts.sources"))
+	if err != nil {
+		return err
+	}
+	templatesDir := ""
+	if projectRoot, err := project.FindRoot("."); err == nil {
+		if sources, err = projectPromptSources(projectRoot); err != nil {
+			return err
+		}
+		if dir, err := projectTemplatesDir(projectRoot); err == nil {
+			templatesDir = dir
+		}
+	}
+
+	set, err := discoverTemplates(sources)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	color.Cyan("Communication Templates:")
+	fmt.Println()
+	if len(set.Templates) == 0 {
+		fmt.Printf("  No templates in %s\n", sources)
+		return nil
+	}
+
+	var pulled map[string]prompts.PullStatus
+	if templatesDir != "" {
+		pulled = templateStatus(set.Templates, templatesDir)
+	}
+	category := ""
+	for _, template := range set.Templates {
+		if template.Category != category {
+			category = template.Category
+			color.Yellow("%s/", category)
+		}
+		indent := "  "
+		if category != "" {
+			indent = "    "
+		}
+		line := indent + color.GreenString(path.Base(template.Path))
+		if len(sources) > 1 {
+			line += color.New(color.Faint).Sprintf("  (%s)", template.Source.Repository)
+		}
+		switch pulled[template.Path] {
+		case prompts.PullUnchanged:
+			line += "  ✓ in project"
+		case prompts.PullSkipped:
+			line += "  ✓ in project, edited"
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Println()
+	color.Yellow("Usage:")
+	fmt.Println("  Copy into the project: now-sc templates pull [template...]")
+	return nil
+}
+
+func runTemplatesPull(cmd *cobra.Command, args []string) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+	templatesDir, err := projectTemplatesDir(projectRoot)
+	if err != nil {
+		return err
+	}
+	sources, err := projectPromptSources(projectRoot)
+	if err != nil {
+		return err
+	}
+	return pullTemplates(sources, templatesDir, args, templatesForce)
+}
+
+// pullTemplates copies the named templates of sources, or all of them, into
+// dir and reports each file. It fails if any template could not be written.
+func pullTemplates(sources project.PromptSources, dir string, names []string, overwrite bool) error {
+	set, err := discoverTemplates(sources)
+	if err != nil {
+		return err
+	}
+
+	templates := set.Templates
+	if len(names) > 0 {
+		templates = nil
+		for _, name := range names {
+			template, err := findTemplate(set.Templates, name)
+			if err != nil {
+				return err
+			}
+			templates = append(templates, template)
+		}
+	}
+	if len(templates) == 0 {
+		color.Yellow("No communication templates in %s", sources)
+		return nil
+	}
+
+	failed := 0
+	for _, result := range prompts.PullTemplates(templates, dir, overwrite) {
+		switch result.Status {
+		case prompts.PullWritten:
+			color.Green("  ✓ %s", result.Template.Path)
+		case prompts.PullUnchanged:
+			fmt.Printf("  - %s (up to date)\n", result.Template.Path)
+		case prompts.PullSkipped:
+			color.Yellow("  - %s (edited locally, kept; use --force to overwrite)", result.Template.Path)
+		case prompts.PullFailed:
+			failed++
+			color.Red("  ✗ %s: %v", result.Template.Path, result.Err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d template(s) could not be written", failed, len(templates))
+	}
+	return nil
+}
+
+// discoverTemplates lists the templates of sources and reports cached copies
+func discoverTemplates(sources project.PromptSources) (*prompts.TemplateSet, error) {
+	set, err := prompts.DiscoverTemplates(githubClient(), sources)
+	if err != nil {
+		return nil, err
+	}
+	for _, stale := range set.Stale {
+		color.Yellow("Warning: %s", stale)
+	}
+	return set, nil
+}
+
+// findTemplate picks a template by path, category/name or name. A bare name
+// shared by several categories is ambiguous.
+func findTemplate(templates []prompts.Template, name string) (prompts.Template, error) {
+	var matches []prompts.Template
+	for _, template := range templates {
+		if template.Matches(name) {
+			matches = append(matches, template)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return prompts.Template{}, fmt.Errorf("template not found: %s (run \"now-sc templates list\")", name)
+	case 1:
+		return matches[0], nil
+	}
+	paths := make([]string, len(matches))
+	for i, match := range matches {
+		paths[i] = match.Path
+	}
+	return prompts.Template{}, fmt.Errorf("template %s is ambiguous: %s", name, strings.Join(paths, ", "))
+}
+
+// templateStatus reports which templates are already in dir and whether they were edited
+func templateStatus(templates []prompts.Template, dir string) map[string]prompts.PullStatus {
+	status := map[string]prompts.PullStatus{}
+	for _, template := range templates {
+		local, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(template.Path)))
+		switch {
+		case err != nil:
+		case bytes.Equal(local, template.Content):
+			status[template.Path] = prompts.PullUnchanged
+		default:
+			status[template.Path] = prompts.PullSkipped
+		}
+	}
+	return status
+}
+
+// projectTemplatesDir is the project's communication templates folder
+func projectTemplatesDir(projectRoot string) (string, error) {
+	layout, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return "", err
+	}
+	dir := layout.Path(project.RoleCommunicationTemplates)
+	if dir == "" {
+		return "", fmt.Errorf("the project layout has no communication templates folder")
+	}
+	return filepath.Join(projectRoot, dir), nil
+}
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/git"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	upgradeDryRun bool
+	upgradeForce  bool
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade the project to the current layout version",
+	Long: `Brings a project created with an older version of now-sc up to the current
+layout: runs the layout migrations (adding and renaming folders, moving files),
+creates any folders that are missing, stores the current layout in
+` + project.LayoutFile + ` and updates the manifest. Folders the project's own layout
+adds, for example from an archetype, are kept.
+
+The upgrade refuses to run on a git repository with uncommitted changes so
+that it can be reviewed with "git diff" and reverted; use --force to run anyway.
+
+Examples:
+  now-sc upgrade --dry-run   # show what would change
+  now-sc upgrade`,
+	RunE: runUpgrade,
+}
+
+func init() {
+	upgradeCmd.Flags().BoolVar(&upgradeDryRun, "dry-run", false, "Show the changes without making them")
+	upgradeCmd.Flags().BoolVar(&upgradeForce, "force", false, "Upgrade even if the working tree has uncommitted changes")
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	from, err := project.ProjectLayoutVersion(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	standard, err := project.LoadLayout()
+	if err != nil {
+		return fmt.Errorf("failed to load project layout: %w", err)
+	}
+	current, err := project.LoadProjectLayout(projectRoot)
+	if err != nil {
+		return err
+	}
+	target, err := project.UpgradeLayout(current, from, standard)
+	if err != nil {
+		return err
+	}
+
+	changes, err := project.PlanUpgrade(projectRoot, from, target)
+	if err != nil {
+		color.Red("Error: %v", err)
+		return err
+	}
+
+	hasManifest := fileExists(filepath.Join(projectRoot, project.ManifestFile))
+	hasLayout := fileExists(filepath.Join(projectRoot, project.LayoutFile))
+	if len(changes) == 0 && hasManifest && hasLayout && from == target.Version {
+		color.Green("✓ Project is up to date (layout version %d)", target.Version)
+		return nil
+	}
+
+	// Show the plan
+	fmt.Println()
+	color.Cyan("Upgrade from layout version %d to %d:", from, target.Version)
+	fmt.Println()
+	for _, c := range changes {
+		switch c.Action {
+		case project.ChangeAdd:
+			fmt.Printf("  %s %s/", color.GreenString("+"), c.To)
+		case project.ChangeRename:
+			fmt.Printf("  %s %s/ → %s/", color.YellowString("~"), c.From, c.To)
+		case project.ChangeMove:
+			fmt.Printf("  %s %s → %s/", color.YellowString("→"), c.From, c.To)
+		}
+		fmt.Printf("  %s\n", color.New(color.Faint).Sprint(c.Reason))
+	}
+	if hasLayout {
+		fmt.Printf("  %s %s (layout version %d)\n", color.YellowString("~"), project.LayoutFile, target.Version)
+	} else {
+		fmt.Printf("  %s %s (layout version %d)\n", color.GreenString("+"), project.LayoutFile, target.Version)
+	}
+	if hasManifest {
+		fmt.Printf("  %s %s (layout_version: %d → %d)\n", color.YellowString("~"), project.ManifestFile, from, target.Version)
+	} else {
+		fmt.Printf("  %s %s\n", color.GreenString("+"), project.ManifestFile)
+	}
+	fmt.Println()
+
+	if upgradeDryRun {
+		color.Yellow("Dry run: no changes made.")
+		return nil
+	}
+
+	// Make sure the upgrade can be reviewed and reverted
+	isRepo := git.IsAvailable() && git.IsRepo(projectRoot)
+	if isRepo {
+		dirty, err := git.Changes(projectRoot)
+		if err != nil {
+			return err
+		}
+		if len(dirty) > 0 && !upgradeForce {
+			color.Red("Error: the working tree has %d uncommitted change(s):", len(dirty))
+			for i, line := range dirty {
+				if i == 10 {
+					fmt.Printf("  ... and %d more\n", len(dirty)-10)
+					break
+				}
+				fmt.Printf("  %s\n", line)
+			}
+			color.Yellow("Commit or stash them first so the upgrade can be reviewed and reverted, or rerun with --force.")
+			return fmt.Errorf("working tree has uncommitted changes")
+		}
+	} else {
+		color.Yellow("Note: the project is not a git repository, so the upgrade cannot be reverted with git.")
+	}
+
+	if ok, err := confirm("Apply these changes"); err != nil {
+		return err
+	} else if !ok {
+		color.Yellow("Upgrade cancelled.")
+		return nil
+	}
+
+	if err := project.ApplyChanges(projectRoot, changes); err != nil {
+		return err
+	}
+	if err := target.Save(projectRoot); err != nil {
+		return err
+	}
+
+	manifest := inferManifest(projectRoot, target)
+	if hasManifest {
+		if manifest, err = project.LoadManifest(projectRoot); err != nil {
+			return err
+		}
+		manifest.LayoutVersion = target.Version
+	}
+	if err := manifest.Save(projectRoot); err != nil {
+		return err
+	}
+
+	color.Green("✓ Project upgraded to layout version %d", target.Version)
+	if isRepo {
+		fmt.Println("Review the changes with \"git status\" and commit them.")
+	}
+	return nil
+}
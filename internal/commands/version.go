@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show the version and the built-in prompt snapshot",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("now-sc %s\n", rootCmd.Version)
+		snapshot := prompts.SnapshotVersion()
+		if snapshot == "" {
+			snapshot = "none"
+		}
+		fmt.Printf("Built-in prompts: %s\n", snapshot)
+	},
+}
@@ -0,0 +1,215 @@
+package comms
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+// Limits on the project data sent to the model
+const (
+	maxOutputs     = 5
+	maxOutputChars = 6000
+)
+
+// Document is a saved prompt output
+type Document struct {
+	Path     string // Relative to the project root
+	Modified time.Time
+	Content  string
+}
+
+// Data is what the project knows that a communication can draw on
+type Data struct {
+	Project     string
+	Created     time.Time
+	Date        time.Time
+	Customer    *project.CustomerProfile // Nil when the project has no customer
+	Outputs     []Document               // Most recent first
+	ActionItems []string
+}
+
+// Gather collects the project's dates, the customer profile and the latest
+// outputs saved in the layout's save folders and the customer's folder,
+// along with the action items found in them
+func Gather(projectRoot string, layout *project.Layout, manifest *project.Manifest, customer *project.CustomerProfile) (*Data, error) {
+	data := &Data{
+		Project:  manifest.Name,
+		Created:  manifest.Created,
+		Date:     time.Now(),
+		Customer: customer,
+	}
+	if data.Project == "" {
+		data.Project = filepath.Base(projectRoot)
+	}
+
+	var dirs []string
+	for _, target := range layout.SaveTargets() {
+		dirs = append(dirs, target.Path)
+	}
+	if customer != nil {
+		dirs = append(dirs, filepath.Join(layout.Path(project.RoleCustomers), customer.Name))
+	}
+
+	var outputs []Document
+	for _, dir := range dirs {
+		found, err := markdownFiles(projectRoot, dir)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, found...)
+	}
+	sort.SliceStable(outputs, func(i, j int) bool { return outputs[i].Modified.After(outputs[j].Modified) })
+	if len(outputs) > maxOutputs {
+		outputs = outputs[:maxOutputs]
+	}
+
+	seen := map[string]bool{}
+	for i := range outputs {
+		content, err := os.ReadFile(filepath.Join(projectRoot, outputs[i].Path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", outputs[i].Path, err)
+		}
+		outputs[i].Content = string(content)
+		for _, item := range ActionItems(outputs[i].Content) {
+			if !seen[strings.ToLower(item)] {
+				seen[strings.ToLower(item)] = true
+				data.ActionItems = append(data.ActionItems, item)
+			}
+		}
+		if len(outputs[i].Content) > maxOutputChars {
+			outputs[i].Content = truncate(outputs[i].Content, maxOutputChars) + "\n[truncated]"
+		}
+	}
+	data.Outputs = outputs
+	return data, nil
+}
+
+// truncate cuts s to at most n bytes without splitting a rune
+func truncate(s string, n int) string {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// markdownFiles lists the markdown files below dir, which may not exist
+func markdownFiles(projectRoot, dir string) ([]Document, error) {
+	var documents []Document
+	root := filepath.Join(projectRoot, dir)
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil, nil
+	}
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(projectRoot, path)
+		if err != nil {
+			return err
+		}
+		documents = append(documents, Document{Path: filepath.ToSlash(rel), Modified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	return documents, nil
+}
+
+var (
+	openCheckbox     = regexp.MustCompile(`^\s*[-*+]\s+\[ \]\s+(.+)$`)
+	actionHeading    = regexp.MustCompile(`(?i)^#{1,6}\s*.*\b(action items?|next steps|to-?dos?|follow[- ]ups?)\b`)
+	doneCheckbox     = regexp.MustCompile(`^\s*[-*+]\s+\[[xX]\]\s`)
+	markdownListItem = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+(.+)$`)
+	markdownHeading  = regexp.MustCompile(`^#{1,6}\s`)
+)
+
+// ActionItems finds open checkboxes and the list items under headings such
+// as "Action Items" or "Next Steps" in a markdown document. Ticked
+// checkboxes are done and left out.
+func ActionItems(markdown string) []string {
+	var items []string
+	inSection := false
+	for _, line := range strings.Split(markdown, "\n") {
+		if markdownHeading.MatchString(line) {
+			inSection = actionHeading.MatchString(line)
+			continue
+		}
+		if match := openCheckbox.FindStringSubmatch(line); match != nil {
+			items = append(items, strings.TrimSpace(match[1]))
+			continue
+		}
+		if inSection && !doneCheckbox.MatchString(line) {
+			if match := markdownListItem.FindStringSubmatch(line); match != nil {
+				items = append(items, strings.TrimSpace(match[1]))
+			}
+		}
+	}
+	return items
+}
+
+// Values returns the placeholders filled from project data alone
+func (d *Data) Values() map[string]string {
+	values := map[string]string{
+		"project":      d.Project,
+		"project.name": d.Project,
+		"date":         d.Date.Format("January 2, 2006"),
+		"action_items": bulletList(d.ActionItems),
+	}
+	if !d.Created.IsZero() {
+		values["project.created"] = d.Created.Format("January 2, 2006")
+	}
+	if d.Customer != nil {
+		for placeholder, value := range d.Customer.Placeholders() {
+			values[strings.Trim(placeholder, "{}")] = value
+		}
+	}
+	for name, value := range values {
+		if value == "" {
+			delete(values, name)
+		}
+	}
+	return values
+}
+
+// Render formats the data as markdown for the model
+func (d *Data) Render() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("# Project: %s\n\n", d.Project))
+	builder.WriteString(fmt.Sprintf("- **Today:** %s\n", d.Date.Format("Monday, January 2, 2006")))
+	if !d.Created.IsZero() {
+		builder.WriteString(fmt.Sprintf("- **Project started:** %s\n", d.Created.Format("January 2, 2006")))
+	}
+	if d.Customer != nil {
+		builder.WriteString("\n" + d.Customer.Render())
+	}
+	if len(d.ActionItems) > 0 {
+		builder.WriteString("\n## Open Action Items\n\n" + bulletList(d.ActionItems) + "\n")
+	}
+	for _, output := range d.Outputs {
+		builder.WriteString(fmt.Sprintf("\n## Output: %s (saved %s)\n\n%s\n", output.Path, output.Modified.Format("2006-01-02"), strings.TrimSpace(output.Content)))
+	}
+	return builder.String()
+}
+
+func bulletList(items []string) string {
+	if len(items) == 0 {
+		return ""
+	}
+	return "- " + strings.Join(items, "\n- ")
+}
@@ -0,0 +1,80 @@
+package comms
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Executor runs a prompt against an AI provider
+type Executor interface {
+	ExecutePrompt(promptContent, userInput string) (string, error)
+}
+
+// BuildFillPrompt returns the system prompt that asks for the values of fields
+func BuildFillPrompt(templateName string, fields []string) string {
+	var builder strings.Builder
+
+	builder.WriteString("You are preparing a customer communication for a ServiceNow presales consultant ")
+	builder.WriteString(fmt.Sprintf("from the template %q. ", templateName))
+	builder.WriteString("Using only the project data you are given, write the value of each of these template fields:\n\n")
+	for _, field := range fields {
+		builder.WriteString("- " + field + "\n")
+	}
+
+	builder.WriteString("\nValues are plain text, not HTML or markdown. Separate lines with \\n and start list items with \"- \". ")
+	builder.WriteString("Keep the tone professional and concise. If the data says nothing about a field, use an empty string rather than inventing facts.\n")
+	builder.WriteString("\nRespond with a single JSON object whose keys are exactly the field names and nothing else, for example:\n")
+	builder.WriteString(`{"status_summary": "The POC is on track for the demo on March 3.", "risks": "- Integration credentials are still pending"}`)
+	builder.WriteString("\n")
+
+	return builder.String()
+}
+
+// ParseFields extracts the field values from a provider response. Fields
+// the response leaves out are missing from the result.
+func ParseFields(response string, fields []string) (map[string]string, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("no JSON object in response: %q", response)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(response[start:end+1]), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse field values: %w", err)
+	}
+
+	values := map[string]string{}
+	for _, field := range fields {
+		switch value := raw[field].(type) {
+		case string:
+			values[field] = strings.TrimSpace(value)
+		case []any:
+			// A list where text was asked for becomes list items
+			items := make([]string, len(value))
+			for i, item := range value {
+				items[i] = "- " + strings.TrimSpace(fmt.Sprint(item))
+			}
+			values[field] = strings.Join(items, "\n")
+		case nil:
+		default:
+			values[field] = fmt.Sprint(value)
+		}
+	}
+	return values, nil
+}
+
+// Fill asks the provider for the values of fields from the project data and
+// any extra context
+func Fill(executor Executor, templateName string, data *Data, extraContext string, fields []string) (map[string]string, error) {
+	input := data.Render()
+	if extraContext != "" {
+		input += "\n" + extraContext
+	}
+	response, err := executor.ExecutePrompt(BuildFillPrompt(templateName, fields), input)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFields(response, fields)
+}
@@ -0,0 +1,97 @@
+package comms
+
+import (
+	"html"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// placeholderPattern matches {{name}} placeholders, as in prompt templates
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// Placeholders returns the distinct placeholder names in a template in the
+// order they first appear
+func Placeholders(template string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, match := range placeholderPattern.FindAllStringSubmatch(template, -1) {
+		name := match[1]
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// IsHTML reports whether a template file is an HTML document
+func IsHTML(fileName string) bool {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".html", ".htm":
+		return true
+	}
+	return false
+}
+
+// Render fills the placeholders of a template with values. In HTML
+// templates values are escaped and line breaks become <br>. Placeholders
+// without a value are left in place so they stand out in the preview.
+func Render(template string, values map[string]string, isHTML bool) string {
+	return placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		value, ok := values[name]
+		if !ok {
+			return placeholder
+		}
+		if isHTML {
+			value = strings.ReplaceAll(html.EscapeString(value), "\n", "<br>\n")
+		}
+		return value
+	})
+}
+
+var (
+	// Elements whose content is never shown
+	hiddenElements = regexp.MustCompile(`(?is)<(head|style|script|title)\b.*?</(head|style|script|title)\s*>|<!--.*?-->`)
+	lineBreaks     = regexp.MustCompile(`(?i)<br\s*/?>`)
+	listItems      = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	listItemEnds   = regexp.MustCompile(`(?i)</li\s*>`)
+	tableCells     = regexp.MustCompile(`(?i)</t[dh]\s*>`)
+	blockEnds      = regexp.MustCompile(`(?i)</?(p|div|h[1-6]|tr|table|ul|ol|blockquote|section|header|footer)\b[^>]*>`)
+	links          = regexp.MustCompile(`(?is)<a\b[^>]*\bhref\s*=\s*["']([^"']+)["'][^>]*>(.*?)</a\s*>`)
+	tags           = regexp.MustCompile(`(?s)<[^>]*>`)
+	spaces         = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankLines     = regexp.MustCompile(`\n{3,}`)
+)
+
+// PlainText converts rendered HTML into the plain-text alternative of an
+// email: block elements become lines, list items get a dash and links keep
+// their target
+func PlainText(document string) string {
+	text := hiddenElements.ReplaceAllString(document, "")
+	text = strings.NewReplacer("\r\n", " ", "\n", " ").Replace(text)
+	text = links.ReplaceAllStringFunc(text, func(link string) string {
+		match := links.FindStringSubmatch(link)
+		label := strings.TrimSpace(tags.ReplaceAllString(match[2], ""))
+		if label == "" || label == match[1] || strings.HasPrefix(match[1], "#") {
+			return match[2]
+		}
+		return label + " (" + match[1] + ")"
+	})
+	text = lineBreaks.ReplaceAllString(text, "\n")
+	text = listItems.ReplaceAllString(text, "\n- ")
+	text = listItemEnds.ReplaceAllString(text, "")
+	text = tableCells.ReplaceAllString(text, "  ")
+	text = blockEnds.ReplaceAllString(text, "\n\n")
+	text = tags.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaces.ReplaceAllString(line, " "))
+	}
+	text = blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text) + "\n"
+}
@@ -0,0 +1,355 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/github"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// Source is a configuration layer. Later layers override earlier ones.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceUser    Source = "user"
+	// SourceCredentials is a secret from the credential store. It is only
+	// consulted when no other layer sets the secret.
+	SourceCredentials Source = "credentials"
+	SourceProject     Source = "project"
+	SourceDotEnv      Source = "dotenv"
+	SourceEnv         Source = "env"
+	SourceFlag        Source = "flag"
+)
+
+// Key describes a configuration setting and where it can be given
+type Key struct {
+	Name        string
+	Env         string // Environment variable, also read from the project .env
+	Flag        string // Command line flag that overrides it, if any
+	Default     string
+	Description string
+	Allowed     []string // Accepted values; empty accepts anything
+	Bool        bool
+	Check       func(string) error // Validates values beyond Allowed and Bool
+	Secret      bool               // Never stored in the project manifest and masked when listed
+}
+
+// Keys are the settings now-sc understands
+var Keys = []Key{
+	{Name: "provider", Env: "NOW_SC_PROVIDER", Flag: "provider", Default: project.ProviderClaude, Description: "AI provider: claude or openrouter", Allowed: []string{project.ProviderClaude, project.ProviderOpenRouter}},
+	{Name: "model", Env: "NOW_SC_MODEL", Flag: "model", Description: "OpenRouter model"},
+	{Name: "openrouter.api_key", Env: "OPENROUTER_API_KEY", Description: "OpenRouter API key", Secret: true},
+	{Name: "github.token", Env: "GITHUB_PAT", Description: "GitHub personal access token", Secret: true},
+	{Name: "github.api_url", Env: "NOW_SC_GITHUB_API_URL", Default: github.DefaultAPIURL, Description: "GitHub API URL, or the host name of a GitHub Enterprise Server", Check: checkAPIURL},
+	{Name: "github.upload_url", Env: "NOW_SC_GITHUB_UPLOAD_URL", Description: "GitHub upload URL, or a host name (default: derived from github.api_url)", Check: checkUploadURL},
+	{Name: "github.org", Env: "NOW_SC_GITHUB_ORG", Default: github.DefaultOrg, Description: "Organization new project repositories are created in; empty for your own account"},
+	{Name: "github.protect_branch", Env: "NOW_SC_GITHUB_PROTECT_BRANCH", Flag: "protect-branch", Default: "false", Description: "Protect the main branch of new project repositories against force pushes and deletion", Bool: true},
+	{Name: "github.required_reviews", Env: "NOW_SC_GITHUB_REQUIRED_REVIEWS", Default: "0", Description: "Approvals a pull request needs on a protected main branch; 0 allows direct pushes", Check: checkCount},
+	{Name: "github.visibility", Env: "NOW_SC_GITHUB_VISIBILITY", Default: github.DefaultVisibility, Description: "Visibility of new project repositories", Allowed: github.Visibilities},
+	{Name: "credentials.store", Env: "NOW_SC_CREDENTIALS_STORE", Default: "auto", Description: "Where auth login stores secrets: auto, keyring or file", Allowed: []string{"auto", "keyring", "file"}},
+	{Name: "prompts.sources", Env: "NOW_SC_PROMPT_SOURCES", Flag: "prompt-source", Default: project.PromptSources{project.DefaultPromptSource}.String(), Description: "Prompt packs as owner/repo[/path][@ref], comma-separated, highest precedence first", Check: checkPromptSources},
+	{Name: "max_size", Env: "NOW_SC_MAX_SIZE", Flag: "max-size", Default: "2MB", Description: "Maximum combined size of prompt context inputs"},
+	{Name: "redact", Env: "NOW_SC_REDACT", Flag: "redact", Default: "true", Description: "Redact sensitive data before sending it to a provider", Bool: true},
+	{Name: "archetype_repo", Env: "NOW_SC_ARCHETYPE_REPO", Flag: "archetype-repo", Description: "Git repository to fetch archetypes from"},
+	{Name: "non_interactive", Env: "NOW_SC_NON_INTERACTIVE", Flag: "non-interactive", Default: "false", Description: "Never prompt", Bool: true},
+}
+
+// Value is a resolved setting and where it came from
+type Value struct {
+	Value  string
+	Source Source
+	Origin string // File, environment variable or flag that set it
+}
+
+// Config holds the resolved value of every key
+type Config struct {
+	values map[string]Value
+}
+
+// FindKey returns the key with the given name
+func FindKey(name string) (Key, error) {
+	for _, key := range Keys {
+		if key.Name == name {
+			return key, nil
+		}
+	}
+	return Key{}, fmt.Errorf("unknown setting %q (known settings: %s)", name, strings.Join(KeyNames(), ", "))
+}
+
+// KeyNames returns the names of all keys, sorted
+func KeyNames() []string {
+	names := make([]string, len(Keys))
+	for i, key := range Keys {
+		names[i] = key.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks that value is acceptable for key
+func (k Key) Validate(value string) error {
+	if k.Bool {
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s must be true or false, got %q", k.Name, value)
+		}
+	}
+	if len(k.Allowed) > 0 {
+		for _, allowed := range k.Allowed {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s must be one of %s, got %q", k.Name, strings.Join(k.Allowed, ", "), value)
+	}
+	if k.Check != nil {
+		if err := k.Check(value); err != nil {
+			return fmt.Errorf("%s: %w", k.Name, err)
+		}
+	}
+	return nil
+}
+
+func checkCount(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n < 0 {
+		return fmt.Errorf("must be a whole number, got %q", value)
+	}
+	return nil
+}
+
+func checkAPIURL(value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := github.ParseAPIURL(value)
+	return err
+}
+
+func checkUploadURL(value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := github.ParseUploadURL(value)
+	return err
+}
+
+func checkPromptSources(value string) error {
+	_, err := project.ParsePromptSources(value)
+	return err
+}
+
+// UserConfigPath returns the user config file, under $XDG_CONFIG_HOME on Linux
+func UserConfigPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "now-sc", "config.yaml"), nil
+}
+
+// Load resolves every key from the built-in defaults, the user config file,
+// the project manifest and .env, and the environment. projectRoot may be
+// empty outside a project. Flags are applied afterwards with Set.
+func Load(projectRoot string) (*Config, error) {
+	c := &Config{values: map[string]Value{}}
+	for _, key := range Keys {
+		c.values[key.Name] = Value{Value: key.Default, Source: SourceDefault}
+	}
+
+	userPath, err := UserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	userValues, err := readUserFile(userPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.apply(userValues, SourceUser, userPath); err != nil {
+		return nil, err
+	}
+
+	if projectRoot != "" {
+		manifest, err := project.LoadManifest(projectRoot)
+		if err != nil {
+			return nil, err
+		}
+		manifestPath := filepath.Join(projectRoot, project.ManifestFile)
+		if err := c.apply(manifestValues(manifest), SourceProject, manifestPath); err != nil {
+			return nil, err
+		}
+
+		envPath := filepath.Join(projectRoot, ".env")
+		dotenv, err := ReadDotEnv(envPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.applyEnv(dotenv.Get, SourceDotEnv, envPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.applyEnv(os.LookupEnv, SourceEnv, ""); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// apply sets the known keys in values, rejecting unknown or invalid ones
+func (c *Config) apply(values map[string]string, source Source, origin string) error {
+	for name, value := range values {
+		key, err := FindKey(name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", origin, err)
+		}
+		if err := key.Validate(value); err != nil {
+			return fmt.Errorf("%s: %w", origin, err)
+		}
+		c.values[name] = Value{Value: value, Source: source, Origin: origin}
+	}
+	return nil
+}
+
+// applyEnv sets every key whose environment variable lookup finds
+func (c *Config) applyEnv(lookup func(string) (string, bool), source Source, file string) error {
+	for _, key := range Keys {
+		value, ok := lookup(key.Env)
+		if !ok || value == "" {
+			continue
+		}
+		origin := key.Env
+		if file != "" {
+			origin = file + " (" + key.Env + ")"
+		}
+		if err := key.Validate(value); err != nil {
+			return fmt.Errorf("%s: %w", origin, err)
+		}
+		c.values[key.Name] = Value{Value: value, Source: source, Origin: origin}
+	}
+	return nil
+}
+
+// Get returns the resolved value of a key
+func (c *Config) Get(name string) string {
+	return c.values[name].Value
+}
+
+// Bool returns the resolved value of a boolean key
+func (c *Config) Bool(name string) bool {
+	value, _ := strconv.ParseBool(c.values[name].Value)
+	return value
+}
+
+// Lookup returns the resolved value of a key along with its origin
+func (c *Config) Lookup(name string) Value {
+	return c.values[name]
+}
+
+// Set overrides a key, typically from a flag
+func (c *Config) Set(name, value string, source Source, origin string) {
+	c.values[name] = Value{Value: value, Source: source, Origin: origin}
+}
+
+// manifestValues returns the settings a project manifest provides
+func manifestValues(manifest *project.Manifest) map[string]string {
+	values := map[string]string{}
+	for name, value := range manifest.Settings {
+		values[name] = value
+	}
+	if manifest.Provider != "" {
+		values["provider"] = manifest.Provider
+	}
+	if manifest.Model != "" {
+		values["model"] = manifest.Model
+	}
+	if len(manifest.Prompts) > 0 {
+		values["prompts.sources"] = manifest.Prompts.String()
+	}
+	return values
+}
+
+// SetProject stores a setting in a project manifest. Secrets are refused
+// because the manifest is committed with the project.
+func SetProject(manifest *project.Manifest, key Key, value string) error {
+	if key.Secret {
+		return fmt.Errorf("%s is a secret and cannot be stored in the project manifest; use the user config or the project .env", key.Name)
+	}
+	switch key.Name {
+	case "provider":
+		manifest.Provider = value
+	case "model":
+		manifest.Model = value
+	case "prompts.sources":
+		// Changing the sources drops the pinned commits; the next fetch resolves new ones
+		sources, err := project.ParsePromptSources(value)
+		if err != nil {
+			return err
+		}
+		manifest.Prompts = sources
+	default:
+		if manifest.Settings == nil {
+			manifest.Settings = map[string]string{}
+		}
+		manifest.Settings[key.Name] = value
+	}
+	return nil
+}
+
+// readUserFile reads the user config file, which need not exist
+func readUserFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var values map[string]string
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// SetUser stores a setting in the user config file. The file may hold
+// secrets, so it is only readable by the user.
+func SetUser(key Key, value string) (string, error) {
+	path, err := UserConfigPath()
+	if err != nil {
+		return "", err
+	}
+	values, err := readUserFile(path)
+	if err != nil {
+		return "", err
+	}
+	if values == nil {
+		values = map[string]string{}
+	}
+	values[key.Name] = value
+
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode user config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// Mask hides most of a secret, keeping enough to recognise it
+func Mask(secret string) string {
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:4] + strings.Repeat("*", 8) + secret[len(secret)-4:]
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DotEnv holds the variables of a .env file
+type DotEnv map[string]string
+
+// Get looks up a variable, matching the signature of os.LookupEnv
+func (d DotEnv) Get(name string) (string, bool) {
+	value, ok := d[name]
+	return value, ok
+}
+
+// ReadDotEnv parses a .env file. A missing file is empty.
+func ReadDotEnv(path string) (DotEnv, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DotEnv{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	env := DotEnv{}
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		name, value, ok, err := parseDotEnvLine(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNumber, err)
+		}
+		if ok {
+			env[name] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return env, nil
+}
+
+// parseDotEnvLine parses NAME=value, optionally prefixed with export. Values
+// may be single or double quoted; unquoted values end at a " #" comment.
+func parseDotEnvLine(line string) (name, value string, ok bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false, nil
+	}
+	line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+	name, value, found := strings.Cut(line, "=")
+	if !found {
+		return "", "", false, fmt.Errorf("expected NAME=value")
+	}
+	name = strings.TrimSpace(name)
+	value = strings.TrimSpace(value)
+
+	switch {
+	case strings.HasPrefix(value, `"`):
+		end := strings.LastIndex(value, `"`)
+		if end == 0 {
+			return "", "", false, fmt.Errorf("unterminated quote in %s", name)
+		}
+		if value, err = strconv.Unquote(value[:end+1]); err != nil {
+			return "", "", false, fmt.Errorf("invalid quoted value for %s: %w", name, err)
+		}
+	case strings.HasPrefix(value, "'"):
+		end := strings.LastIndex(value, "'")
+		if end == 0 {
+			return "", "", false, fmt.Errorf("unterminated quote in %s", name)
+		}
+		value = value[1:end]
+	default:
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+	}
+	return name, value, true, nil
+}
@@ -0,0 +1,96 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Backends a credential store can use. Auto prefers the OS keyring and falls
+// back to the encrypted file when no keyring is reachable, such as on a
+// headless Linux machine without a secret service.
+const (
+	BackendAuto    = "auto"
+	BackendKeyring = "keyring"
+	BackendFile    = "file"
+)
+
+// ErrNotFound is returned when no credential is stored for a provider
+var ErrNotFound = errors.New("no credential stored")
+
+// Provider is a service now-sc stores a credential for
+type Provider struct {
+	Name    string
+	Label   string
+	Setting string // Configuration key the credential supplies
+}
+
+// Providers are the services that can be logged in to
+var Providers = []Provider{
+	{Name: "openrouter", Label: "OpenRouter API key", Setting: "openrouter.api_key"},
+	{Name: "github", Label: "GitHub token", Setting: "github.token"},
+}
+
+// FindProvider returns the provider with the given name
+func FindProvider(name string) (Provider, error) {
+	for _, p := range Providers {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return Provider{}, fmt.Errorf("unknown provider %q (use openrouter or github)", name)
+}
+
+// ProviderForSetting returns the provider that supplies a configuration key
+func ProviderForSetting(setting string) (Provider, bool) {
+	for _, p := range Providers {
+		if p.Setting == setting {
+			return p, true
+		}
+	}
+	return Provider{}, false
+}
+
+// Store keeps provider credentials
+type Store interface {
+	// Name describes where credentials are kept
+	Name() string
+	Get(provider string) (string, error)
+	Set(provider, secret string) error
+	Delete(provider string) error
+}
+
+// PassphraseFunc supplies the passphrase of the encrypted file. create is
+// true when the file does not exist yet and the passphrase will set it.
+type PassphraseFunc func(create bool) (string, error)
+
+// FilePath returns the encrypted credential file in the user config directory
+func FilePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "now-sc", "credentials.enc"), nil
+}
+
+// Open returns the store for backend. The passphrase is only asked for when
+// the encrypted file is read or written.
+func Open(backend string, passphrase PassphraseFunc) (Store, error) {
+	switch backend {
+	case BackendKeyring:
+		if err := probeKeyring(); err != nil {
+			return nil, fmt.Errorf("OS keyring is not available: %w", err)
+		}
+		return keyringStore{}, nil
+	case BackendFile:
+		return newFileStore(passphrase)
+	case BackendAuto, "":
+		if probeKeyring() == nil {
+			return keyringStore{}, nil
+		}
+		return newFileStore(passphrase)
+	default:
+		return nil, fmt.Errorf("unknown credential store %q (use auto, keyring or file)", backend)
+	}
+}
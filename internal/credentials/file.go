@@ -0,0 +1,207 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Key derivation parameters for the encrypted file
+const (
+	fileVersion      = 1
+	kdfIterations    = 600000
+	saltSize         = 16
+	encryptionKeyLen = 32
+)
+
+// encryptedFile is the on-disk format: the credentials as JSON, sealed with
+// AES-256-GCM under a key derived from the passphrase
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// fileStore keeps credentials in a passphrase-encrypted file. It works
+// without a keyring, so it is the fallback on headless machines.
+type fileStore struct {
+	path       string
+	passphrase PassphraseFunc
+	key        string // Passphrase once it has been asked for
+}
+
+func newFileStore(passphrase PassphraseFunc) (*fileStore, error) {
+	path, err := FilePath()
+	if err != nil {
+		return nil, err
+	}
+	return &fileStore{path: path, passphrase: passphrase}, nil
+}
+
+func (s *fileStore) Name() string {
+	return "encrypted file " + s.path
+}
+
+func (s *fileStore) Get(provider string) (string, error) {
+	secrets, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := secrets[provider]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return secret, nil
+}
+
+func (s *fileStore) Set(provider, secret string) error {
+	secrets, err := s.load()
+	if err != nil {
+		return err
+	}
+	secrets[provider] = secret
+	return s.save(secrets)
+}
+
+func (s *fileStore) Delete(provider string) error {
+	secrets, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[provider]; !ok {
+		return ErrNotFound
+	}
+	delete(secrets, provider)
+	if len(secrets) == 0 {
+		if err := os.Remove(s.path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", s.path, err)
+		}
+		return nil
+	}
+	return s.save(secrets)
+}
+
+// load decrypts the file. A missing file holds no credentials and does not
+// ask for the passphrase.
+func (s *fileStore) load() (map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", s.path, err)
+	}
+	if file.Version != fileVersion {
+		return nil, fmt.Errorf("%s has unsupported version %d", s.path, file.Version)
+	}
+
+	if err := s.unlock(false); err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(s.key, file.Salt, file.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		s.key = ""
+		return nil, fmt.Errorf("failed to decrypt %s: wrong passphrase or corrupted file", s.path)
+	}
+
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse decrypted credentials: %w", err)
+	}
+	return secrets, nil
+}
+
+// save encrypts secrets with a fresh salt and nonce and writes the file
+func (s *fileStore) save(secrets map[string]string) error {
+	_, statErr := os.Stat(s.path)
+	if err := s.unlock(os.IsNotExist(statErr)); err != nil {
+		return err
+	}
+
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+
+	file := encryptedFile{Version: fileVersion, Iterations: kdfIterations, Salt: make([]byte, saltSize)}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	gcm, err := newGCM(s.key, file.Salt, file.Iterations)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	file.Data = gcm.Seal(nil, file.Nonce, plain, nil)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", s.path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+
+	// Write to a temporary file first so a failed write never loses credentials
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// unlock asks for the passphrase once per store
+func (s *fileStore) unlock(create bool) error {
+	if s.key != "" {
+		return nil
+	}
+	if s.passphrase == nil {
+		return fmt.Errorf("%s is encrypted and no passphrase is available", s.path)
+	}
+	key, err := s.passphrase(create)
+	if err != nil {
+		return err
+	}
+	if key == "" {
+		return fmt.Errorf("the passphrase must not be empty")
+	}
+	s.key = key
+	return nil
+}
+
+// newGCM derives the encryption key from the passphrase
+func newGCM(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, encryptionKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
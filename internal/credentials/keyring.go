@@ -0,0 +1,47 @@
+package credentials
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringService is the service name credentials are filed under
+const keyringService = "now-sc"
+
+// keyringStore keeps credentials in the OS keyring: Keychain on macOS,
+// Credential Manager on Windows and the Secret Service on Linux
+type keyringStore struct{}
+
+// probeKeyring checks that the keyring can be reached
+func probeKeyring() error {
+	_, err := keyring.Get(keyringService, "probe")
+	if err == nil || errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (keyringStore) Name() string {
+	return "OS keyring"
+}
+
+func (keyringStore) Get(provider string) (string, error) {
+	secret, err := keyring.Get(keyringService, provider)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return secret, err
+}
+
+func (keyringStore) Set(provider, secret string) error {
+	return keyring.Set(keyringService, provider, secret)
+}
+
+func (keyringStore) Delete(provider string) error {
+	err := keyring.Delete(keyringService, provider)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,172 @@
+package git
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// IsAvailable reports whether the git executable can be found
+func IsAvailable() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+}
+
+// run executes git in dir and returns its trimmed output
+func run(dir string, args ...string) (string, error) {
+	return runEnv(dir, nil, args...)
+}
+
+// runEnv executes git in dir with extra environment variables
+func runEnv(dir string, env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// IsRepo reports whether dir is inside a git work tree
+func IsRepo(dir string) bool {
+	out, err := run(dir, "rev-parse", "--is-inside-work-tree")
+	return err == nil && out == "true"
+}
+
+// Changes returns the uncommitted changes in dir, one porcelain status line per file
+func Changes(dir string) ([]string, error) {
+	out, err := run(dir, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// RemoteURL returns the URL of a remote, or an empty string if it is not configured
+func RemoteURL(dir, name string) string {
+	out, err := run(dir, "remote", "get-url", name)
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
+// AheadBehind returns how many commits the current branch is ahead of and
+// behind its upstream. ok is false when the branch has no upstream.
+func AheadBehind(dir string) (ahead, behind int, ok bool) {
+	out, err := run(dir, "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
+	if err != nil {
+		return 0, 0, false
+	}
+
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+	ahead, errAhead := strconv.Atoi(fields[0])
+	behind, errBehind := strconv.Atoi(fields[1])
+	if errAhead != nil || errBehind != nil {
+		return 0, 0, false
+	}
+	return ahead, behind, true
+}
+
+// Clone makes a shallow clone of url into dir, checking out ref if it is set
+func Clone(url, ref, dir string) error {
+	args := []string{"clone", "--depth", "1", "--quiet"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	_, err := run(".", append(args, url, dir)...)
+	return err
+}
+
+// Init creates a repository in dir whose first branch is branch
+func Init(dir, branch string) error {
+	if _, err := run(dir, "init", "--quiet"); err != nil {
+		return err
+	}
+	_, err := run(dir, "symbolic-ref", "HEAD", "refs/heads/"+branch)
+	return err
+}
+
+// HasCommits reports whether the repository in dir has any commit
+func HasCommits(dir string) bool {
+	_, err := run(dir, "rev-parse", "--verify", "--quiet", "HEAD")
+	return err == nil
+}
+
+// CurrentBranch returns the branch checked out in dir
+func CurrentBranch(dir string) (string, error) {
+	return run(dir, "symbolic-ref", "--short", "HEAD")
+}
+
+// AddRemote adds a remote to the repository in dir
+func AddRemote(dir, name, url string) error {
+	_, err := run(dir, "remote", "add", name, url)
+	return err
+}
+
+// Author is who a commit is attributed to
+type Author struct {
+	Name  string
+	Email string
+}
+
+// ConfiguredAuthor returns the identity git is configured with in dir.
+// ok is false when git would refuse to commit for lack of one.
+func ConfiguredAuthor(dir string) (author Author, ok bool) {
+	author.Name, _ = run(dir, "config", "user.name")
+	author.Email, _ = run(dir, "config", "user.email")
+	return author, author.Name != "" && author.Email != ""
+}
+
+// CommitAll stages every file in dir and commits them. An empty author
+// leaves the identity to git's configuration.
+func CommitAll(dir, message string, author Author) error {
+	if _, err := run(dir, "add", "--all"); err != nil {
+		return err
+	}
+	var env []string
+	if author.Name != "" {
+		env = []string{
+			"GIT_AUTHOR_NAME=" + author.Name, "GIT_AUTHOR_EMAIL=" + author.Email,
+			"GIT_COMMITTER_NAME=" + author.Name, "GIT_COMMITTER_EMAIL=" + author.Email,
+		}
+	}
+	_, err := runEnv(dir, env, "commit", "--quiet", "--message", message)
+	return err
+}
+
+// Push pushes branch to remote and makes it the branch's upstream. A token
+// is sent as an HTTP header of this push only, passed through the
+// environment so it is neither written to .git/config nor visible in the
+// process list.
+func Push(dir, remote, branch, token string) error {
+	env := []string{"GIT_TERMINAL_PROMPT=0"}
+	if token != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token))
+		env = append(env,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: Basic "+credentials,
+		)
+	}
+	_, err := runEnv(dir, env, "push", "--quiet", "--set-upstream", remote, branch)
+	return err
+}
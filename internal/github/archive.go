@@ -0,0 +1,131 @@
+package github
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// maxArchiveSize caps the download of a repository archive
+const maxArchiveSize = 50 << 20
+
+// DownloadTarball fetches the gzipped tarball of repo at ref
+func (c *Client) DownloadTarball(repo, ref string) ([]byte, error) {
+	req, err := c.newRequest("GET", fmt.Sprintf("/repos/%s/tarball/%s", repo, ref), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", repo, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s was not found; private repositories need a GitHub token (now-sc auth login github)", repo)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxArchiveSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", repo, err)
+	}
+	if len(data) > maxArchiveSize {
+		return nil, fmt.Errorf("archive of %s is larger than %d MB", repo, maxArchiveSize>>20)
+	}
+	if resp.ContentLength >= 0 && int64(len(data)) != resp.ContentLength {
+		return nil, fmt.Errorf("download of %s is incomplete (%d of %d bytes)", repo, len(data), resp.ContentLength)
+	}
+	return data, nil
+}
+
+// ArchiveFile is a file read from an archive
+type ArchiveFile struct {
+	Path    string // Relative to the extracted folder, with forward slashes
+	Content []byte
+}
+
+// ExtractArchive reads every entry of a GitHub tarball, which fails on a
+// truncated or corrupted download, and returns the files under dir that keep
+// accepts. GitHub names the archive's top folder owner-repo-<short sha>,
+// which must match commit, so a stale or substituted archive is rejected.
+func ExtractArchive(archive []byte, commit, dir string, keep func(path string) bool) ([]ArchiveFile, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+
+	prefix := strings.Trim(dir, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	var files []ArchiveFile
+	topDir := ""
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+
+		top, rest, _ := strings.Cut(header.Name, "/")
+		if topDir == "" {
+			topDir = top
+			sha := topDir[strings.LastIndex(topDir, "-")+1:]
+			if len(sha) < 7 || !strings.HasPrefix(commit, sha) {
+				return nil, fmt.Errorf("archive is of %s, not commit %.7s", topDir, commit)
+			}
+		} else if top != topDir {
+			return nil, fmt.Errorf("unexpected entry %s outside %s", header.Name, topDir)
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || !strings.HasPrefix(rest, prefix) {
+			continue
+		}
+
+		rel := strings.TrimPrefix(rest, prefix)
+		if !filepath.IsLocal(filepath.FromSlash(rel)) || path.Clean(rel) != rel {
+			return nil, fmt.Errorf("unsafe path %s", header.Name)
+		}
+		if keep == nil || keep(rel) {
+			files = append(files, ArchiveFile{Path: rel, Content: content})
+		}
+	}
+
+	// The gzip checksum is only verified once the stream is read to the end
+	if _, err := io.Copy(io.Discard, gz); err != nil {
+		return nil, err
+	}
+	if topDir == "" {
+		return nil, fmt.Errorf("archive is empty")
+	}
+	return files, nil
+}
+
+// statusError describes an unexpected GitHub API response
+func statusError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return fmt.Errorf("GitHub API rate limit reached; authenticate with a GitHub token (now-sc auth login github) to raise it")
+	}
+	return fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+}
@@ -1,105 +1,224 @@
 package github
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
-	"os/exec"
-	"path/filepath"
+	"net/url"
 	"strings"
 )
 
+// Defaults for github.com
 const (
-	GitHubBaseURL       = "https://api.github.com/repos/Now-AI-Foundry/Now-SC-Base-Prompts/contents/Prompts"
-	GitHubAPIURL        = "https://api.github.com"
-	GitHubOrg           = "Now-AI-Foundry"
-	TemplateBaseURL     = "https://raw.githubusercontent.com/Now-AI-Foundry/Now-SC-Base-Prompts/main/Templates"
+	DefaultAPIURL     = "https://api.github.com"
+	DefaultUploadURL  = "https://uploads.github.com"
+	DefaultOrg        = "Now-AI-Foundry"
+	DefaultVisibility = "private"
 )
 
-type GitHubFile struct {
-	Name        string `json:"name"`
-	Type        string `json:"type"`
-	DownloadURL string `json:"download_url"`
+// Visibilities a new repository can have; internal needs an organization on
+// GitHub Enterprise
+var Visibilities = []string{"private", "internal", "public"}
+
+// Client talks to the API of github.com or a GitHub Enterprise Server
+type Client struct {
+	APIURL string // https://api.github.com, or https://<host>/api/v3 for GitHub Enterprise Server
+	// UploadURL serves endpoints that take file uploads, such as release
+	// assets; empty derives it from APIURL
+	UploadURL  string
+	Org        string // Organization new repositories are created in; empty for the token's own account
+	Visibility string // Visibility of new repositories
+	Token      string // May be empty for public repositories
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client for github.com authenticated with token
+func NewClient(token string) *Client {
+	return &Client{APIURL: DefaultAPIURL, Org: DefaultOrg, Visibility: DefaultVisibility, Token: token, HTTPClient: http.DefaultClient}
+}
+
+// ParseAPIURL checks an API base URL. A bare GitHub Enterprise host name or
+// URL without a path gets the /api/v3 path of the REST API.
+func ParseAPIURL(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+	value = strings.TrimRight(value, "/")
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", fmt.Errorf("invalid GitHub API URL %q", value)
+	}
+	if u.Path == "" && u.Host != "api.github.com" {
+		u.Path = "/api/v3"
+	}
+	return u.String(), nil
+}
+
+// ParseUploadURL checks an upload base URL. A bare GitHub Enterprise host
+// name or URL without a path gets the /api/uploads path of the server.
+func ParseUploadURL(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+	value = strings.TrimRight(value, "/")
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", fmt.Errorf("invalid GitHub upload URL %q", value)
+	}
+	if u.Path == "" && u.Host != "uploads.github.com" {
+		u.Path = "/api/uploads"
+	}
+	return u.String(), nil
+}
+
+// Uploads returns the upload base URL, derived from the API URL unless set
+func (c *Client) Uploads() string {
+	if c.UploadURL != "" {
+		return strings.TrimRight(c.UploadURL, "/")
+	}
+	if c.APIURL == DefaultAPIURL {
+		return DefaultUploadURL
+	}
+	return strings.TrimSuffix(c.APIURL, "/v3") + "/uploads"
+}
+
+// WebURL returns the address of the web interface, for links shown to users
+func (c *Client) WebURL() string {
+	u, err := url.Parse(c.APIURL)
+	if err != nil || c.APIURL == DefaultAPIURL {
+		return "https://github.com"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// newRequest prepares an API request to path, authenticated when a token is set
+func (c *Client) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, strings.TrimRight(c.APIURL, "/")+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "token "+c.Token)
+	}
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
 }
 
 type GitHubRepo struct {
+	FullName string `json:"full_name"`
 	CloneURL string `json:"clone_url"`
 	HTMLURL  string `json:"html_url"`
 }
 
-// FetchAndSavePrompts fetches prompts from GitHub and saves them
-func FetchAndSavePrompts(projectPath string) error {
-	resp, err := http.Get(GitHubBaseURL)
+// TokenInfo describes a personal access token
+type TokenInfo struct {
+	Login       string
+	Scopes      []string
+	FineGrained bool // Fine-grained tokens do not report OAuth scopes
+}
+
+// CheckToken looks up the user the client's token belongs to and the scopes it grants
+func (c *Client) CheckToken() (*TokenInfo, error) {
+	req, err := c.newRequest("GET", "/user", nil)
 	if err != nil {
-		return fmt.Errorf("failed to fetch prompts: %w", err)
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach GitHub: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("token was rejected (expired or revoked)")
+	}
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
 	}
 
-	var files []GitHubFile
-	if err := json.NewDecoder(resp.Body).Decode(&files); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	promptsPath := filepath.Join(projectPath, "10_PromptTemplates")
-
-	for _, file := range files {
-		if file.Type == "file" && strings.HasSuffix(file.Name, ".md") {
-			content, err := downloadFile(file.DownloadURL)
-			if err != nil {
-				return fmt.Errorf("failed to download %s: %w", file.Name, err)
-			}
-
-			filePath := filepath.Join(promptsPath, file.Name)
-			if err := os.WriteFile(filePath, content, 0644); err != nil {
-				return fmt.Errorf("failed to save %s: %w", file.Name, err)
-			}
+	info := &TokenInfo{Login: user.Login}
+	header, ok := resp.Header["X-Oauth-Scopes"]
+	if !ok {
+		info.FineGrained = true
+		return info, nil
+	}
+	for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			info.Scopes = append(info.Scopes, scope)
 		}
 	}
+	return info, nil
+}
 
-	return nil
+// CommitRef is a ref resolved to a commit
+type CommitRef struct {
+	SHA         string
+	ETag        string // Validator for revalidating the ref later
+	NotModified bool   // The ref still points where etag says; SHA is empty
 }
 
-// FetchCommunicationTemplates fetches communication templates
-func FetchCommunicationTemplates(projectPath string) error {
-	templates := []struct {
-		URL      string
-		Filename string
-	}{
-		{
-			URL:      TemplateBaseURL + "/servicenow_poc_status_template.html",
-			Filename: "servicenow_poc_status_template.html",
-		},
+// ResolveCommit returns the commit ref points to in repo. When etag is set
+// and the ref has not moved, GitHub answers without counting the request
+// against the rate limit and NotModified is set.
+func (c *Client) ResolveCommit(repo, ref, etag string) (*CommitRef, error) {
+	req, err := c.newRequest("GET", fmt.Sprintf("/repos/%s/commits/%s", repo, url.PathEscape(ref)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.sha")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
 	}
 
-	templatesPath := filepath.Join(projectPath, "30_CommunicationTemplates")
-
-	for _, template := range templates {
-		content, err := downloadFile(template.URL)
-		if err != nil {
-			// Just warn, don't fail
-			continue
-		}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach GitHub: %w", err)
+	}
+	defer resp.Body.Close()
 
-		filePath := filepath.Join(templatesPath, template.Filename)
-		if err := os.WriteFile(filePath, content, 0644); err != nil {
-			continue
-		}
+	if resp.StatusCode == http.StatusNotModified {
+		return &CommitRef{ETag: etag, NotModified: true}, nil
+	}
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity {
+		return nil, fmt.Errorf("%s has no branch, tag or commit %q, or the token cannot read it", repo, ref)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp)
 	}
 
-	return nil
+	sha, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &CommitRef{SHA: strings.TrimSpace(string(sha)), ETag: resp.Header.Get("ETag")}, nil
 }
 
-// CreateRepository creates a GitHub repository
-func CreateRepository(projectPath, projectName, customerName string) error {
-	token := os.Getenv("GITHUB_PAT")
-	if token == "" {
-		return fmt.Errorf("GITHUB_PAT environment variable not set")
+// CreateRepository creates an empty repository for a project in the
+// client's organization, or in the token's own account when there is none or
+// the token may not create repositories there
+func (c *Client) CreateRepository(projectName, customerName string) (*GitHubRepo, error) {
+	if c.Token == "" {
+		return nil, fmt.Errorf("no GitHub token configured")
 	}
 
 	// Sanitize repo name
@@ -112,100 +231,69 @@ func CreateRepository(projectPath, projectName, customerName string) error {
 
 	description := fmt.Sprintf("Presales project for %s", customerName)
 
-	// Try to create in organization first
-	repo, err := createOrgRepository(token, repoName, description)
-	if err != nil {
-		// If org creation fails due to permissions, try user account
-		if strings.Contains(err.Error(), "403") || strings.Contains(err.Error(), "admin access") {
-			fmt.Printf("Note: Cannot create in %s organization. Creating in your personal account instead...\n", GitHubOrg)
-			repo, err = createUserRepository(token, repoName, description)
-			if err != nil {
-				return err
-			}
-		} else {
-			return err
+	var repo *GitHubRepo
+	var err error
+	if c.Org != "" {
+		// Try to create in organization first
+		repo, err = c.createRepository(fmt.Sprintf("/orgs/%s/repos", url.PathEscape(c.Org)), repoName, description, c.Visibility)
+		if err != nil && (strings.Contains(err.Error(), "403") || strings.Contains(err.Error(), "admin access")) {
+			// If org creation fails due to permissions, try user account
+			fmt.Printf("Note: Cannot create in %s organization. Creating in your personal account instead...\n", c.Org)
+			repo, err = nil, nil
+		}
+		if err != nil {
+			return nil, err
 		}
 	}
-
-	// Initialize git repository
-	if err := initGitRepo(projectPath, repo.CloneURL); err != nil {
-		return fmt.Errorf("failed to initialize git: %w", err)
+	if repo == nil {
+		// Internal visibility only exists for organizations
+		visibility := c.Visibility
+		if visibility == "internal" {
+			visibility = "private"
+		}
+		if repo, err = c.createRepository("/user/repos", repoName, description, visibility); err != nil {
+			return nil, err
+		}
 	}
-
-	fmt.Printf("Repository URL: %s\n", repo.HTMLURL)
-
-	return nil
+	return repo, nil
 }
 
-func createOrgRepository(token, repoName, description string) (*GitHubRepo, error) {
-	reqBody := fmt.Sprintf(`{
-		"name": "%s",
-		"description": "%s",
-		"private": true,
-		"auto_init": false
-	}`, repoName, description)
-
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/orgs/%s/repos", GitHubAPIURL, GitHubOrg), strings.NewReader(reqBody))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// createRepository creates a repository through the endpoint at path
+func (c *Client) createRepository(path, repoName, description, visibility string) (*GitHubRepo, error) {
+	if visibility == "" {
+		visibility = DefaultVisibility
 	}
-
-	req.Header.Set("Authorization", "token "+token)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	reqBody, err := json.Marshal(map[string]any{
+		"name":        repoName,
+		"description": description,
+		"private":     visibility != "public",
+		"visibility":  visibility,
+		"auto_init":   false,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create repository: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusUnprocessableEntity {
-		return nil, fmt.Errorf("repository \"%s\" already exists in %s organization", repoName, GitHubOrg)
-	}
-
-	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("failed to encode request: %w", err)
 	}
 
-	var repo GitHubRepo
-	if err := json.NewDecoder(resp.Body).Decode(&repo); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return &repo, nil
-}
-
-func createUserRepository(token, repoName, description string) (*GitHubRepo, error) {
-	reqBody := fmt.Sprintf(`{
-		"name": "%s",
-		"description": "%s",
-		"private": true,
-		"auto_init": false
-	}`, repoName, description)
-
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/user/repos", GitHubAPIURL), strings.NewReader(reqBody))
+	req, err := c.newRequest("POST", path, bytes.NewReader(reqBody))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
-
-	req.Header.Set("Authorization", "token "+token)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create repository: %w", err)
 	}
 	defer resp.Body.Close()
 
+	owner := "your account"
+	if strings.HasPrefix(path, "/orgs/") {
+		owner = c.Org + " organization"
+	}
 	if resp.StatusCode == http.StatusUnprocessableEntity {
 		body, _ := io.ReadAll(resp.Body)
 		if strings.Contains(string(body), "already exists") {
-			return nil, fmt.Errorf("repository \"%s\" already exists in your account", repoName)
+			return nil, fmt.Errorf("repository \"%s\" already exists in %s", repoName, owner)
 		}
 		return nil, fmt.Errorf("GitHub API returned status %d: %s", resp.StatusCode, string(body))
 	}
@@ -223,34 +311,45 @@ func createUserRepository(token, repoName, description string) (*GitHubRepo, err
 	return &repo, nil
 }
 
-func initGitRepo(projectPath, repoURL string) error {
-	commands := [][]string{
-		{"git", "init"},
-		{"git", "remote", "add", "origin", repoURL},
-		{"git", "branch", "-M", "main"},
+// ProtectBranch applies protection defaults to branch of repo (owner/name):
+// force pushes and deletion are blocked and, when reviews is above zero,
+// changes need a pull request with that many approvals
+func (c *Client) ProtectBranch(repo, branch string, reviews int) error {
+	var pullRequests any
+	if reviews > 0 {
+		pullRequests = map[string]any{"required_approving_review_count": reviews}
 	}
-
-	for _, cmdArgs := range commands {
-		cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
-		cmd.Dir = projectPath
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("failed to run %s: %w", strings.Join(cmdArgs, " "), err)
-		}
+	reqBody, err := json.Marshal(map[string]any{
+		"required_status_checks":        nil,
+		"enforce_admins":                false,
+		"required_pull_request_reviews": pullRequests,
+		"restrictions":                  nil,
+		"allow_force_pushes":            false,
+		"allow_deletions":               false,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
 	}
 
-	return nil
-}
+	req, err := c.newRequest("PUT", fmt.Sprintf("/repos/%s/branches/%s/protection", repo, url.PathEscape(branch)), bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
 
-func downloadFile(url string) ([]byte, error) {
-	resp, err := http.Get(url)
+	resp, err := c.do(req)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to reach GitHub: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GitHub refused branch protection (status %d): %s; private repositories need a plan that supports it and the token needs admin access", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GitHub API returned status %d: %s", resp.StatusCode, string(body))
 	}
-
-	return io.ReadAll(resp.Body)
+	return nil
 }
@@ -0,0 +1,158 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeGitHub records repository creation requests and answers them with
+// the status set for each path
+func fakeGitHub(t *testing.T, status map[string]int) (*Client, *[]map[string]any) {
+	t.Helper()
+	var created []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token test-token" {
+			t.Errorf("%s %s: missing token", r.Method, r.URL.Path)
+		}
+		code, ok := status[r.URL.Path]
+		if !ok || r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("%s: invalid body: %v", r.URL.Path, err)
+		}
+		body["path"] = r.URL.Path
+		created = append(created, body)
+
+		w.WriteHeader(code)
+		if code == http.StatusCreated {
+			json.NewEncoder(w).Encode(map[string]string{
+				"full_name": "owner/" + body["name"].(string),
+				"clone_url": "https://example.com/owner/" + body["name"].(string) + ".git",
+			})
+			return
+		}
+		if code == http.StatusForbidden {
+			w.Write([]byte(`{"message":"You need admin access to the organization before adding a repository to it."}`))
+			return
+		}
+		w.Write([]byte(`{"message":"Server Error"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient("test-token")
+	client.APIURL = srv.URL
+	client.Org = "acme"
+	return client, &created
+}
+
+func TestCreateRepositoryInOrganization(t *testing.T) {
+	client, created := fakeGitHub(t, map[string]int{"/orgs/acme/repos": http.StatusCreated})
+	client.Visibility = "internal"
+
+	repo, err := client.CreateRepository("Big Deal", "Acme Corp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.FullName != "owner/Big-Deal" {
+		t.Errorf("FullName = %q, want owner/Big-Deal", repo.FullName)
+	}
+	if len(*created) != 1 {
+		t.Fatalf("got %d create requests, want 1", len(*created))
+	}
+	if got := (*created)[0]["visibility"]; got != "internal" {
+		t.Errorf("visibility = %v, want internal", got)
+	}
+}
+
+func TestCreateRepositoryFallsBackToUserAccount(t *testing.T) {
+	client, created := fakeGitHub(t, map[string]int{
+		"/orgs/acme/repos": http.StatusForbidden,
+		"/user/repos":      http.StatusCreated,
+	})
+	client.Visibility = "internal"
+
+	if _, err := client.CreateRepository("deal", "Acme Corp"); err != nil {
+		t.Fatal(err)
+	}
+	if len(*created) != 2 {
+		t.Fatalf("got %d create requests, want 2", len(*created))
+	}
+	user := (*created)[1]
+	if user["path"] != "/user/repos" {
+		t.Errorf("fallback went to %v, want /user/repos", user["path"])
+	}
+	// Personal accounts have no internal visibility
+	if user["visibility"] != "private" || user["private"] != true {
+		t.Errorf("fallback visibility = %v, private = %v, want private", user["visibility"], user["private"])
+	}
+}
+
+func TestCreateRepositoryWithoutOrganization(t *testing.T) {
+	client, created := fakeGitHub(t, map[string]int{"/user/repos": http.StatusCreated})
+	client.Org = ""
+
+	if _, err := client.CreateRepository("deal", "Acme Corp"); err != nil {
+		t.Fatal(err)
+	}
+	if len(*created) != 1 || (*created)[0]["path"] != "/user/repos" {
+		t.Errorf("requests = %v, want a single /user/repos", *created)
+	}
+}
+
+func TestCreateRepositoryOtherErrorsDoNotFallBack(t *testing.T) {
+	client, created := fakeGitHub(t, map[string]int{
+		"/orgs/acme/repos": http.StatusInternalServerError,
+		"/user/repos":      http.StatusCreated,
+	})
+
+	if _, err := client.CreateRepository("deal", "Acme Corp"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(*created) != 1 {
+		t.Errorf("got %d create requests, want 1", len(*created))
+	}
+}
+
+func TestParseURLs(t *testing.T) {
+	tests := []struct {
+		parse func(string) (string, error)
+		in    string
+		want  string
+	}{
+		{ParseAPIURL, "github.example.com", "https://github.example.com/api/v3"},
+		{ParseAPIURL, "https://api.github.com/", "https://api.github.com"},
+		{ParseAPIURL, "http://localhost:8080/custom", "http://localhost:8080/custom"},
+		{ParseUploadURL, "github.example.com", "https://github.example.com/api/uploads"},
+		{ParseUploadURL, "https://uploads.github.com", "https://uploads.github.com"},
+		{ParseUploadURL, "https://proxy.example.com/uploads/", "https://proxy.example.com/uploads"},
+	}
+	for _, tt := range tests {
+		got, err := tt.parse(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parse(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseUploadURL("ftp://example.com"); err == nil {
+		t.Error("ParseUploadURL accepted an ftp URL")
+	}
+}
+
+func TestUploads(t *testing.T) {
+	client := NewClient("")
+	if got := client.Uploads(); got != DefaultUploadURL {
+		t.Errorf("github.com uploads = %q", got)
+	}
+	client.APIURL = "https://github.example.com/api/v3"
+	if got := client.Uploads(); got != "https://github.example.com/api/uploads" {
+		t.Errorf("enterprise uploads = %q", got)
+	}
+	client.UploadURL = "https://proxy.example.com/uploads/"
+	if got := client.Uploads(); got != "https://proxy.example.com/uploads" {
+		t.Errorf("configured uploads = %q", got)
+	}
+}
@@ -0,0 +1,191 @@
+package inbox
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// StateFile is where processing state is kept, relative to the project root
+	StateFile = ".now-sc/inbox-state.json"
+	// ArchiveDirName is the folder inside the inbox that processed files are moved to
+	ArchiveDirName = "_archive"
+)
+
+// Status describes whether an inbox file has been processed
+type Status string
+
+const (
+	StatusNew       Status = "new"
+	StatusProcessed Status = "processed"
+	StatusChanged   Status = "changed"
+)
+
+// Run records a single prompt execution that used an inbox file as context
+type Run struct {
+	Template   string    `json:"template"`
+	RanAt      time.Time `json:"ran_at"`
+	OutputPath string    `json:"output_path,omitempty"`
+}
+
+// Record is the processing history of one inbox file
+type Record struct {
+	Hash string `json:"hash"`
+	Runs []Run  `json:"runs"`
+}
+
+// State maps project-relative inbox paths (slash separated) to their history
+type State struct {
+	Files map[string]*Record `json:"files"`
+}
+
+// LoadState reads the processing state of a project, returning an empty
+// state if none has been recorded yet
+func LoadState(projectRoot string) (*State, error) {
+	state := &State{Files: map[string]*Record{}}
+
+	data, err := os.ReadFile(filepath.Join(projectRoot, StateFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read inbox state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", StateFile, err)
+	}
+	if state.Files == nil {
+		state.Files = map[string]*Record{}
+	}
+	return state, nil
+}
+
+// Save writes the processing state back to the project
+func (s *State) Save(projectRoot string) error {
+	path := filepath.Join(projectRoot, StateFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode inbox state: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write inbox state: %w", err)
+	}
+	return nil
+}
+
+// Status compares a file's current content hash with the recorded one
+func (s *State) Status(projectRoot, relPath string) (Status, error) {
+	record, ok := s.Files[stateKey(relPath)]
+	if !ok || len(record.Runs) == 0 {
+		return StatusNew, nil
+	}
+
+	hash, err := HashFile(filepath.Join(projectRoot, relPath))
+	if err != nil {
+		return "", err
+	}
+	if hash != record.Hash {
+		return StatusChanged, nil
+	}
+	return StatusProcessed, nil
+}
+
+// Lookup returns the record for a file, or nil if it was never processed
+func (s *State) Lookup(relPath string) *Record {
+	return s.Files[stateKey(relPath)]
+}
+
+// RecordRun stores a prompt run against a file along with its current hash
+func (s *State) RecordRun(projectRoot, relPath, template, outputPath string) error {
+	hash, err := HashFile(filepath.Join(projectRoot, relPath))
+	if err != nil {
+		return err
+	}
+
+	key := stateKey(relPath)
+	record, ok := s.Files[key]
+	if !ok {
+		record = &Record{}
+		s.Files[key] = record
+	}
+	record.Hash = hash
+	record.Runs = append(record.Runs, Run{
+		Template:   template,
+		RanAt:      time.Now(),
+		OutputPath: filepath.ToSlash(outputPath),
+	})
+	return nil
+}
+
+// Archive moves a processed file into the archive folder of inboxDir,
+// keeping its path below the inbox, and carries its history over to the new
+// location. It returns the new path relative to the project root.
+func (s *State) Archive(projectRoot, inboxDir, relPath string) (string, error) {
+	inner, err := filepath.Rel(inboxDir, relPath)
+	if err != nil || inner == ".." || strings.HasPrefix(inner, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is not inside %s", relPath, inboxDir)
+	}
+
+	newRel := filepath.Join(inboxDir, ArchiveDirName, inner)
+	target := filepath.Join(projectRoot, newRel)
+	if _, err := os.Stat(target); err == nil {
+		return "", fmt.Errorf("%s already exists", newRel)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	if err := os.Rename(filepath.Join(projectRoot, relPath), target); err != nil {
+		return "", fmt.Errorf("failed to archive %s: %w", relPath, err)
+	}
+
+	s.Move(relPath, newRel)
+	return newRel, nil
+}
+
+// Move carries a file's history over after it has been moved on disk
+func (s *State) Move(oldPath, newPath string) {
+	if record, ok := s.Files[stateKey(oldPath)]; ok {
+		delete(s.Files, stateKey(oldPath))
+		s.Files[stateKey(newPath)] = record
+	}
+}
+
+// IsArchived reports whether a project-relative path lies in the archive
+// folder of inboxDir
+func IsArchived(inboxDir, relPath string) bool {
+	archive := stateKey(filepath.Join(inboxDir, ArchiveDirName))
+	key := stateKey(relPath)
+	return key == archive || strings.HasPrefix(key, archive+"/")
+}
+
+// HashFile returns the hex encoded SHA-256 of a file's content
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func stateKey(relPath string) string {
+	return filepath.ToSlash(filepath.Clean(relPath))
+}
@@ -0,0 +1,236 @@
+package inbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+// Category is the kind of document a triaged inbox file was classified as
+type Category string
+
+const (
+	CategoryInternalCall   Category = "internal_call"
+	CategoryExternalCall   Category = "external_call"
+	CategoryEmail          Category = "email"
+	CategoryRequirementDoc Category = "requirement_doc"
+	CategoryPricing        Category = "pricing"
+	CategoryOther          Category = "other"
+)
+
+// Categories lists every category with the description given to the model
+var Categories = []struct {
+	Category    Category
+	Description string
+}{
+	{CategoryInternalCall, "Notes or transcript of a call with only our own team (no customer present)"},
+	{CategoryExternalCall, "Notes or transcript of a call or meeting with the customer or a partner"},
+	{CategoryEmail, "An email or email thread"},
+	{CategoryRequirementDoc, "Customer requirements, RFP/RFI content, use cases or scope documents"},
+	{CategoryPricing, "Pricing, licensing, quotes, commercial terms or deal structure"},
+	{CategoryOther, "Anything that does not fit the categories above"},
+}
+
+// maxClassifyChars limits how much of each file is sent for classification
+const maxClassifyChars = 8000
+
+// Executor runs a prompt against an AI provider
+type Executor interface {
+	ExecutePrompt(promptContent, userInput string) (string, error)
+}
+
+// Classification is the provider's verdict for a single file
+type Classification struct {
+	Category Category `json:"category"`
+	Customer string   `json:"customer"`
+	Reason   string   `json:"reason"`
+}
+
+// Proposal is a suggested move for a single inbox file
+type Proposal struct {
+	Source         string // Path relative to project root
+	Destination    string // Directory relative to project root, empty if the file should stay
+	Classification Classification
+}
+
+// ListUntriaged returns the files waiting to be triaged: everything in the
+// layout's unsorted inbox folder plus loose files directly in the inbox
+func ListUntriaged(projectRoot string, layout *project.Layout) ([]string, error) {
+	inboxPath := filepath.Join(projectRoot, layout.Path(project.RoleInbox))
+	if _, err := os.Stat(inboxPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("inbox directory not found at: %s", inboxPath)
+	}
+
+	dirs := []string{inboxPath}
+	if unsorted := layout.Path(project.RoleInboxUnsorted); unsorted != "" {
+		dirs = append(dirs, filepath.Join(projectRoot, unsorted))
+	}
+
+	var files []string
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			relPath, err := filepath.Rel(projectRoot, filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, relPath)
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// BuildClassificationPrompt returns the system prompt used to classify a file
+func BuildClassificationPrompt(customers []string) string {
+	var builder strings.Builder
+
+	builder.WriteString("You are triaging files in a presales consultant's inbox. ")
+	builder.WriteString("Classify the file you are given into exactly one of these categories:\n\n")
+	for _, c := range Categories {
+		builder.WriteString(fmt.Sprintf("- %s: %s\n", c.Category, c.Description))
+	}
+
+	if len(customers) > 0 {
+		builder.WriteString("\nKnown customers: ")
+		builder.WriteString(strings.Join(customers, ", "))
+		builder.WriteString("\nIf the file clearly belongs to one of these customers, set \"customer\" to that exact name, otherwise leave it empty.\n")
+	}
+
+	builder.WriteString("\nRespond with a single JSON object and nothing else, for example:\n")
+	builder.WriteString(`{"category": "external_call", "customer": "", "reason": "Discovery call transcript with the customer's IT team"}`)
+	builder.WriteString("\n")
+
+	return builder.String()
+}
+
+// ParseClassification extracts the JSON verdict from a provider response
+func ParseClassification(response string) (*Classification, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("no JSON object in response: %q", response)
+	}
+
+	var c Classification
+	if err := json.Unmarshal([]byte(response[start:end+1]), &c); err != nil {
+		return nil, fmt.Errorf("failed to parse classification: %w", err)
+	}
+
+	c.Category = Category(strings.ToLower(strings.TrimSpace(string(c.Category))))
+	for _, known := range Categories {
+		if c.Category == known.Category {
+			return &c, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown category %q", c.Category)
+}
+
+// truncate cuts s to at most n bytes without splitting a rune
+func truncate(s string, n int) string {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// Classify asks the provider to classify a single file
+func Classify(executor Executor, projectRoot, relPath string, customers []string) (*Classification, error) {
+	content, err := os.ReadFile(filepath.Join(projectRoot, relPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", relPath, err)
+	}
+
+	text := string(content)
+	if len(text) > maxClassifyChars {
+		text = truncate(text, maxClassifyChars) + "\n[truncated]"
+	}
+
+	input := fmt.Sprintf("File name: %s\n\n%s", filepath.Base(relPath), text)
+	response, err := executor.ExecutePrompt(BuildClassificationPrompt(customers), input)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseClassification(response)
+}
+
+// Destination returns the directory a classified file should be moved to,
+// as given by the layout's triage targets. Requirement and pricing documents
+// go to the customer folder, which is only known when the model named a
+// customer or the project has just one.
+func Destination(c Classification, customers []string, layout *project.Layout) string {
+	switch c.Category {
+	case CategoryRequirementDoc, CategoryPricing:
+		if target, ok := layout.TriageTargets()[string(c.Category)]; ok {
+			return target
+		}
+		customersPath := layout.Path(project.RoleCustomers)
+		if customer := matchCustomer(c.Customer, customers); customer != "" {
+			return filepath.Join(customersPath, customer)
+		}
+		if len(customers) == 1 {
+			return filepath.Join(customersPath, customers[0])
+		}
+		return ""
+	case CategoryOther:
+		return ""
+	}
+	return layout.TriageTargets()[string(c.Category)]
+}
+
+func matchCustomer(name string, customers []string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+	for _, customer := range customers {
+		if strings.EqualFold(customer, name) {
+			return customer
+		}
+	}
+	return ""
+}
+
+// Apply moves the file described by a proposal into its destination,
+// adding a numeric suffix instead of overwriting an existing file.
+// It returns the new path relative to the project root.
+func Apply(projectRoot string, p Proposal) (string, error) {
+	destDir := filepath.Join(projectRoot, p.Destination)
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	name := filepath.Base(p.Source)
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	target := filepath.Join(destDir, name)
+	for i := 1; ; i++ {
+		if _, err := os.Stat(target); os.IsNotExist(err) {
+			break
+		}
+		target = filepath.Join(destDir, fmt.Sprintf("%s_%d%s", stem, i, ext))
+	}
+
+	if err := os.Rename(filepath.Join(projectRoot, p.Source), target); err != nil {
+		return "", fmt.Errorf("failed to move %s: %w", p.Source, err)
+	}
+
+	return filepath.Rel(projectRoot, target)
+}
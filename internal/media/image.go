@@ -0,0 +1,140 @@
+package media
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // register GIF decoder
+	"image/jpeg"
+	"image/png"
+	"path/filepath"
+	"strings"
+)
+
+// MaxDimension is the longest side, in pixels, an image is downscaled to
+// before it is sent to a model
+const MaxDimension = 1568
+
+// MaxImageSize caps the size of a single image input before downscaling
+const MaxImageSize = 20 * 1024 * 1024
+
+// mediaTypes maps supported image extensions to their MIME type
+var mediaTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+// Image is an image input ready to be sent to a vision-capable model
+type Image struct {
+	Name      string // Label shown to the model, usually the project-relative path
+	MediaType string // MIME type of Data
+	Data      []byte
+}
+
+// IsImage reports whether a path or URL names a supported image file
+func IsImage(path string) bool {
+	_, ok := mediaTypes[strings.ToLower(filepath.Ext(path))]
+	return ok
+}
+
+// Prepare validates raw image bytes and downscales them so the longest side
+// is at most MaxDimension. WebP images cannot be decoded with the standard
+// library and are passed through unchanged.
+func Prepare(name string, data []byte) (*Image, error) {
+	mediaType, ok := mediaTypes[strings.ToLower(filepath.Ext(name))]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a supported image type", name)
+	}
+	if len(data) > MaxImageSize {
+		return nil, fmt.Errorf("image %s is larger than %d MB", name, MaxImageSize/(1024*1024))
+	}
+	if mediaType == "image/webp" {
+		return &Image{Name: name, MediaType: mediaType, Data: data}, nil
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image %s: %w", name, err)
+	}
+
+	bounds := src.Bounds()
+	if bounds.Dx() <= MaxDimension && bounds.Dy() <= MaxDimension {
+		return &Image{Name: name, MediaType: mediaType, Data: data}, nil
+	}
+
+	scaled := downscale(src, MaxDimension)
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: 85})
+		mediaType = "image/jpeg"
+	} else {
+		err = png.Encode(&buf, scaled)
+		mediaType = "image/png"
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image %s: %w", name, err)
+	}
+
+	return &Image{Name: name, MediaType: mediaType, Data: buf.Bytes()}, nil
+}
+
+// DataURL returns the image as a base64 data URL
+func (i *Image) DataURL() string {
+	return "data:" + i.MediaType + ";base64," + base64.StdEncoding.EncodeToString(i.Data)
+}
+
+// downscale resizes src so its longest side is maxDim, averaging every
+// source pixel that falls into each destination pixel
+func downscale(src image.Image, maxDim int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	dw, dh := maxDim, h*maxDim/w
+	if h > w {
+		dw, dh = w*maxDim/h, maxDim
+	}
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0 := bounds.Min.Y + y*h/dh
+		y1 := bounds.Min.Y + (y+1)*h/dh
+		for x := 0; x < dw; x++ {
+			x0 := bounds.Min.X + x*w/dw
+			x1 := bounds.Min.X + (x+1)*w/dw
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			if n == 0 {
+				continue
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
@@ -6,21 +6,59 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/media"
 )
 
 const (
 	OpenRouterAPIURL = "https://openrouter.ai/api/v1/chat/completions"
+	ModelsAPIURL     = "https://openrouter.ai/api/v1/models"
+	KeyAPIURL        = "https://openrouter.ai/api/v1/key"
 	DefaultModel     = "google/gemini-2.0-flash-exp:free"
 )
 
 type Client struct {
 	apiKey string
+	model  string
 	client *http.Client
 }
 
+// Message is a chat message. Content is either a string or, for
+// multimodal requests, a slice of ContentPart.
 type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role    string      `json:"role"`
+	Content interface{} `json:"content"`
+}
+
+// ContentPart is one element of an OpenAI-style content array
+type ContentPart struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	ImageURL *ImageURL `json:"image_url,omitempty"`
+}
+
+// ImageURL references an image by URL or base64 data URL
+type ImageURL struct {
+	URL string `json:"url"`
+}
+
+type modelsResponse struct {
+	Data []struct {
+		ID           string `json:"id"`
+		Architecture struct {
+			Modality        string   `json:"modality"`
+			InputModalities []string `json:"input_modalities"`
+		} `json:"architecture"`
+	} `json:"data"`
+}
+
+// KeyInfo describes the API key in use
+type KeyInfo struct {
+	Label      string   `json:"label"`
+	Usage      float64  `json:"usage"`
+	Limit      *float64 `json:"limit"`
+	IsFreeTier bool     `json:"is_free_tier"`
 }
 
 type Request struct {
@@ -40,18 +78,138 @@ type Response struct {
 func NewClient(apiKey string) *Client {
 	return &Client{
 		apiKey: apiKey,
+		model:  DefaultModel,
 		client: &http.Client{},
 	}
 }
 
+// SetModel selects the model used for subsequent requests
+func (c *Client) SetModel(model string) {
+	if model != "" {
+		c.model = model
+	}
+}
+
+// Model returns the model used for requests
+func (c *Client) Model() string {
+	return c.model
+}
+
 // ExecutePrompt executes a prompt using OpenRouter API
 func (c *Client) ExecutePrompt(promptContent, userInput string) (string, error) {
 	if userInput == "" {
 		userInput = "Please provide guidance based on the system prompt."
 	}
 
+	return c.send(promptContent, userInput)
+}
+
+// ExecutePromptWithImages executes a prompt with images attached as content
+// parts. It fails before sending anything if the model does not accept images.
+func (c *Client) ExecutePromptWithImages(promptContent, userInput string, images []media.Image) (string, error) {
+	if len(images) == 0 {
+		return c.ExecutePrompt(promptContent, userInput)
+	}
+
+	vision, err := c.SupportsVision()
+	if err != nil {
+		return "", err
+	}
+	if !vision {
+		return "", fmt.Errorf("model %s does not support image inputs; choose a vision-capable model with --model", c.model)
+	}
+
+	if userInput == "" {
+		userInput = "Please provide guidance based on the system prompt."
+	}
+
+	parts := []ContentPart{{Type: "text", Text: userInput}}
+	for i := range images {
+		parts = append(parts,
+			ContentPart{Type: "text", Text: fmt.Sprintf("Image: %s", images[i].Name)},
+			ContentPart{Type: "image_url", ImageURL: &ImageURL{URL: images[i].DataURL()}},
+		)
+	}
+
+	return c.send(promptContent, parts)
+}
+
+// SupportsVision looks up the selected model in the OpenRouter model list and
+// reports whether it accepts image input
+func (c *Client) SupportsVision() (bool, error) {
+	req, err := http.NewRequest("GET", ModelsAPIURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up model capabilities: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("OpenRouter API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var models modelsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&models); err != nil {
+		return false, fmt.Errorf("failed to decode model list: %w", err)
+	}
+
+	for _, m := range models.Data {
+		if m.ID != c.model {
+			continue
+		}
+		for _, modality := range m.Architecture.InputModalities {
+			if modality == "image" {
+				return true, nil
+			}
+		}
+		input := strings.SplitN(m.Architecture.Modality, "->", 2)[0]
+		return strings.Contains(input, "image"), nil
+	}
+
+	return false, fmt.Errorf("model %s not found on OpenRouter", c.model)
+}
+
+// ValidateKey checks the API key without spending credits and returns its details
+func (c *Client) ValidateKey() (*KeyInfo, error) {
+	req, err := http.NewRequest("GET", KeyAPIURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach OpenRouter: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("API key was rejected")
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("OpenRouter API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var key struct {
+		Data KeyInfo `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&key); err != nil {
+		return nil, fmt.Errorf("failed to decode key details: %w", err)
+	}
+	return &key.Data, nil
+}
+
+// send posts a system prompt and a user message to the chat completions API
+func (c *Client) send(promptContent string, userContent interface{}) (string, error) {
 	reqBody := Request{
-		Model: DefaultModel,
+		Model: c.model,
 		Messages: []Message{
 			{
 				Role:    "system",
@@ -59,7 +217,7 @@ func (c *Client) ExecutePrompt(promptContent, userInput string) (string, error)
 			},
 			{
 				Role:    "user",
-				Content: userInput,
+				Content: userContent,
 			},
 		},
 	}
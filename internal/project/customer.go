@@ -0,0 +1,185 @@
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CustomerProfileFile is the profile stored in each customer folder
+const CustomerProfileFile = "customer.yaml"
+
+// Contact is a person at the customer
+type Contact struct {
+	Name  string `yaml:"name"`
+	Role  string `yaml:"role,omitempty"`
+	Email string `yaml:"email,omitempty"`
+	Phone string `yaml:"phone,omitempty"`
+}
+
+// CustomerProfile describes a customer in a project
+type CustomerProfile struct {
+	Name        string    `yaml:"name"`
+	Industry    string    `yaml:"industry,omitempty"`
+	Region      string    `yaml:"region,omitempty"`
+	InstanceURL string    `yaml:"instance_url,omitempty"`
+	Contacts    []Contact `yaml:"contacts,omitempty"`
+	Notes       string    `yaml:"notes,omitempty"`
+}
+
+// ValidateCustomerName rejects names that cannot be used as a folder name
+func ValidateCustomerName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("customer name is required")
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid customer name %q", name)
+	}
+	return nil
+}
+
+// CustomerDir returns the folder of a customer
+func CustomerDir(projectRoot string, layout *Layout, name string) string {
+	return filepath.Join(projectRoot, layout.Path(RoleCustomers), name)
+}
+
+// CustomerNames returns the customer folder names in the layout's customers folder
+func CustomerNames(projectRoot string, layout *Layout) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(projectRoot, layout.Path(RoleCustomers)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read customers directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// LoadCustomer reads a customer's profile, matching the name case-insensitively.
+// Customer folders without a profile get one containing just the name.
+func LoadCustomer(projectRoot string, layout *Layout, name string) (*CustomerProfile, error) {
+	names, err := CustomerNames(projectRoot, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	folder := ""
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			folder = n
+			break
+		}
+	}
+	if folder == "" {
+		return nil, fmt.Errorf("customer not found: %s", name)
+	}
+
+	profile := &CustomerProfile{Name: folder}
+	data, err := os.ReadFile(filepath.Join(CustomerDir(projectRoot, layout, folder), CustomerProfileFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return profile, nil
+		}
+		return nil, fmt.Errorf("failed to read customer profile: %w", err)
+	}
+	if err := yaml.Unmarshal(data, profile); err != nil {
+		return nil, fmt.Errorf("failed to parse profile of %s: %w", folder, err)
+	}
+	profile.Name = folder
+	return profile, nil
+}
+
+// Save writes the profile into the customer's folder, creating it if needed
+func (p *CustomerProfile) Save(projectRoot string, layout *Layout) error {
+	if err := ValidateCustomerName(p.Name); err != nil {
+		return err
+	}
+
+	dir := CustomerDir(projectRoot, layout, p.Name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create customer directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(p); err != nil {
+		return fmt.Errorf("failed to encode customer profile: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, CustomerProfileFile), buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write customer profile: %w", err)
+	}
+	return nil
+}
+
+// Render formats the profile as markdown for inclusion in a prompt
+func (p *CustomerProfile) Render() string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("## Customer Profile: %s\n\n", p.Name))
+	for _, field := range []struct{ label, value string }{
+		{"Industry", p.Industry},
+		{"Region", p.Region},
+		{"ServiceNow instance", p.InstanceURL},
+	} {
+		if field.value != "" {
+			builder.WriteString(fmt.Sprintf("- **%s:** %s\n", field.label, field.value))
+		}
+	}
+
+	if len(p.Contacts) > 0 {
+		builder.WriteString("\n**Contacts:**\n")
+		for _, c := range p.Contacts {
+			line := c.Name
+			for _, detail := range []string{c.Role, c.Email, c.Phone} {
+				if detail != "" {
+					line += ", " + detail
+				}
+			}
+			builder.WriteString("- " + line + "\n")
+		}
+	}
+
+	if p.Notes != "" {
+		builder.WriteString("\n" + strings.TrimSpace(p.Notes) + "\n")
+	}
+
+	return builder.String()
+}
+
+// Placeholders returns the template placeholders a profile fills in
+func (p *CustomerProfile) Placeholders() map[string]string {
+	return map[string]string{
+		"{{customer}}":              p.Name,
+		"{{customer.name}}":         p.Name,
+		"{{customer.industry}}":     p.Industry,
+		"{{customer.region}}":       p.Region,
+		"{{customer.instance_url}}": p.InstanceURL,
+		"{{customer.profile}}":      p.Render(),
+	}
+}
+
+// ApplyToTemplate fills customer placeholders in a prompt template. Templates
+// without placeholders get the rendered profile appended instead.
+func (p *CustomerProfile) ApplyToTemplate(template string) string {
+	if !strings.Contains(template, "{{customer") {
+		return strings.TrimRight(template, "\n") + "\n\n" + p.Render()
+	}
+
+	pairs := make([]string, 0, 12)
+	for placeholder, value := range p.Placeholders() {
+		pairs = append(pairs, placeholder, value)
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
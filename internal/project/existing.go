@@ -0,0 +1,142 @@
+package project
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ListFiles returns every file under dir relative to it, with forward slashes
+func ListFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// BackupPath returns the timestamped archive path Backup writes for dir
+func BackupPath(dir string, now time.Time) string {
+	return filepath.Clean(dir) + "-backup-" + now.Format("20060102-150405") + ".tar.gz"
+}
+
+// Backup writes dir and everything in it to a gzipped tarball at archivePath
+func Backup(dir, archivePath string) error {
+	file, err := os.OpenFile(archivePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	base := filepath.Base(filepath.Clean(dir))
+
+	walkErr := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(filepath.Join(base, rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+
+	// Close everything so the archive is complete before anything is removed
+	tarErr := tw.Close()
+	gzErr := gz.Close()
+	fileErr := file.Close()
+	for _, err := range []error{walkErr, tarErr, gzErr, fileErr} {
+		if err != nil {
+			os.Remove(archivePath)
+			return fmt.Errorf("failed to back up %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// MergeMissing moves the files and folders of src that do not exist in dst
+// into dst, leaving everything already in dst untouched. It returns the
+// added paths relative to dst and the paths that were kept.
+func MergeMissing(src, dst string) (added, kept []string, err error) {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", src, err)
+	}
+
+	for _, entry := range entries {
+		from := filepath.Join(src, entry.Name())
+		to := filepath.Join(dst, entry.Name())
+
+		info, statErr := os.Stat(to)
+		switch {
+		case os.IsNotExist(statErr):
+			if err := os.Rename(from, to); err != nil {
+				return added, kept, fmt.Errorf("failed to add %s: %w", to, err)
+			}
+			added = append(added, entry.Name())
+		case statErr != nil:
+			return added, kept, statErr
+		case entry.IsDir() && info.IsDir():
+			subAdded, subKept, err := MergeMissing(from, to)
+			for _, p := range subAdded {
+				added = append(added, entry.Name()+"/"+p)
+			}
+			for _, p := range subKept {
+				kept = append(kept, entry.Name()+"/"+p)
+			}
+			if err != nil {
+				return added, kept, err
+			}
+		default:
+			kept = append(kept, entry.Name())
+		}
+	}
+	return added, kept, nil
+}
@@ -0,0 +1,113 @@
+package project
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var fileTemplates embed.FS
+
+// ProjectFile is a file generated in every new project from a template
+type ProjectFile struct {
+	Path     string // Relative to the project root
+	Template string // Template file name
+}
+
+// ProjectFiles are the files CreateProjectFiles renders
+var ProjectFiles = []ProjectFile{
+	{Path: "README.md", Template: "README.md.tmpl"},
+	{Path: ".env.example", Template: "env.example.tmpl"},
+	{Path: ".gitignore", Template: "gitignore.tmpl"},
+}
+
+// ProjectFileData is what project file templates are rendered with
+type ProjectFileData struct {
+	Name          string
+	Customer      string
+	Created       string
+	Provider      string
+	Model         string
+	Archetype     string
+	LayoutVersion int
+	Tree          string // The layout as an indented tree
+	Structure     string // The layout as a markdown list with descriptions
+	Layout        *Layout
+}
+
+// TemplateOverrideDirs returns the directories searched for project file
+// templates before the built-in ones: the user's first, then the org's
+func TemplateOverrideDirs() []string {
+	var dirs []string
+	if configDir, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(configDir, "now-sc", "templates"))
+	}
+	if orgDir := os.Getenv("NOW_SC_ORG_DIR"); orgDir != "" {
+		dirs = append(dirs, filepath.Join(orgDir, "templates"))
+	}
+	return dirs
+}
+
+// loadFileTemplate returns the first override of name, or the built-in template
+func loadFileTemplate(name string) (*template.Template, error) {
+	for _, dir := range TemplateOverrideDirs() {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read template %s: %w", path, err)
+		}
+		tmpl, err := template.New(name).Parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+		}
+		return tmpl, nil
+	}
+
+	tmpl, err := template.ParseFS(fileTemplates, "templates/"+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse built-in template %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// CreateProjectFiles renders the README, .env.example and .gitignore from
+// their templates
+func CreateProjectFiles(projectPath string, manifest *Manifest, layout *Layout) error {
+	data := ProjectFileData{
+		Name:          manifest.Name,
+		Customer:      manifest.Customer,
+		Created:       manifest.Created.Format("2006-01-02"),
+		Provider:      manifest.Provider,
+		Model:         manifest.Model,
+		Archetype:     manifest.Archetype,
+		LayoutVersion: layout.Version,
+		Tree:          strings.Join(layout.Tree(manifest.Customer), "\n"),
+		Structure:     readmeStructure(layout, manifest.Customer),
+		Layout:        layout,
+	}
+
+	for _, file := range ProjectFiles {
+		tmpl, err := loadFileTemplate(file.Template)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to render %s: %w", file.Path, err)
+		}
+		if err := os.WriteFile(filepath.Join(projectPath, file.Path), buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %w", file.Path, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,344 @@
+package project
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Folder roles used by commands to find folders in a layout
+const (
+	RoleInbox                  = "inbox"
+	RoleInboxUnsorted          = "inbox_unsorted"
+	RoleCustomers              = "customers"
+	RolePrompts                = "prompts"
+	RoleCommunicationTemplates = "communication_templates"
+)
+
+// requiredRoles must be present in every layout
+var requiredRoles = []string{RoleInbox, RoleCustomers, RolePrompts}
+
+// LayoutFile is where a project's resolved layout is stored, relative to the project root
+const LayoutFile = ".now-sc/layout.yaml"
+
+//go:embed layouts/default.yaml
+var defaultLayout []byte
+
+// Folder is a directory in the project layout
+type Folder struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Role        string   `yaml:"role,omitempty"`
+	SaveLabel   string   `yaml:"save_label,omitempty"`
+	Triage      string   `yaml:"triage,omitempty"`
+	Remove      bool     `yaml:"remove,omitempty"`
+	Children    []Folder `yaml:"children,omitempty"`
+}
+
+// Layout defines the project directory structure
+type Layout struct {
+	Version int      `yaml:"version"`
+	Replace bool     `yaml:"replace,omitempty"`
+	Folders []Folder `yaml:"folders"`
+}
+
+// SaveTarget is an entry in the "save output" menu
+type SaveTarget struct {
+	Label string
+	Path  string // Relative to the project root
+}
+
+// DefaultLayout returns the built-in layout
+func DefaultLayout() *Layout {
+	layout, err := ParseLayout(defaultLayout)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in layout: %v", err))
+	}
+	return layout
+}
+
+// ParseLayout parses a YAML or JSON layout definition
+func ParseLayout(data []byte) (*Layout, error) {
+	var layout Layout
+	if err := yaml.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("failed to parse layout: %w", err)
+	}
+	return &layout, nil
+}
+
+// LayoutOverridePaths returns the org and user layout override files in the
+// order they are applied. Missing files are skipped when loading.
+func LayoutOverridePaths() []string {
+	var paths []string
+	if orgDir := os.Getenv("NOW_SC_ORG_DIR"); orgDir != "" {
+		paths = append(paths, layoutFileIn(orgDir))
+	}
+	if configDir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, layoutFileIn(filepath.Join(configDir, "now-sc")))
+	}
+	return paths
+}
+
+// layoutFileIn returns layout.yaml, layout.yml or layout.json in dir,
+// whichever exists, defaulting to layout.yaml
+func layoutFileIn(dir string) string {
+	for _, name := range []string{"layout.yaml", "layout.yml", "layout.json"} {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, "layout.yaml")
+}
+
+// LoadLayout returns the built-in layout with org and user overrides applied
+func LoadLayout() (*Layout, error) {
+	layout := DefaultLayout()
+
+	for _, path := range LayoutOverridePaths() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read layout override %s: %w", path, err)
+		}
+
+		override, err := ParseLayout(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		layout = layout.Merge(override)
+	}
+
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	return layout, nil
+}
+
+// LoadProjectLayout returns the layout stored in a project, falling back to
+// the configured layout for projects created before layouts were recorded
+func LoadProjectLayout(projectRoot string) (*Layout, error) {
+	data, err := os.ReadFile(filepath.Join(projectRoot, LayoutFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return LoadLayout()
+		}
+		return nil, fmt.Errorf("failed to read project layout: %w", err)
+	}
+
+	layout, err := ParseLayout(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", LayoutFile, err)
+	}
+	if err := layout.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", LayoutFile, err)
+	}
+	return layout, nil
+}
+
+// Save writes the layout into a project so later commands use the same one
+func (l *Layout) Save(projectRoot string) error {
+	path := filepath.Join(projectRoot, LayoutFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(l); err != nil {
+		return fmt.Errorf("failed to encode layout: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write layout: %w", err)
+	}
+	return nil
+}
+
+// Merge returns a copy of l with override applied. Folders are matched by
+// name at each level; fields set in the override win.
+func (l *Layout) Merge(override *Layout) *Layout {
+	if override.Replace {
+		merged := *override
+		merged.Replace = false
+		merged.Folders = mergeFolders(nil, override.Folders)
+		return &merged
+	}
+
+	merged := &Layout{Version: l.Version, Folders: mergeFolders(l.Folders, override.Folders)}
+	if override.Version > 0 {
+		merged.Version = override.Version
+	}
+	return merged
+}
+
+func mergeFolders(base, override []Folder) []Folder {
+	merged := make([]Folder, 0, len(base)+len(override))
+	index := map[string]int{}
+	for _, f := range base {
+		index[f.Name] = len(merged)
+		merged = append(merged, f)
+	}
+
+	removed := map[string]bool{}
+	for _, o := range override {
+		if o.Remove {
+			removed[o.Name] = true
+			continue
+		}
+		i, ok := index[o.Name]
+		if !ok {
+			o.Children = mergeFolders(nil, o.Children)
+			index[o.Name] = len(merged)
+			merged = append(merged, o)
+			continue
+		}
+
+		f := &merged[i]
+		if o.Description != "" {
+			f.Description = o.Description
+		}
+		if o.Role != "" {
+			f.Role = o.Role
+		}
+		if o.SaveLabel != "" {
+			f.SaveLabel = o.SaveLabel
+		}
+		if o.Triage != "" {
+			f.Triage = o.Triage
+		}
+		f.Children = mergeFolders(f.Children, o.Children)
+	}
+
+	result := merged[:0]
+	for _, f := range merged {
+		if !removed[f.Name] {
+			result = append(result, f)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Validate checks folder names are safe and unique and required roles exist
+func (l *Layout) Validate() error {
+	if len(l.Folders) == 0 {
+		return fmt.Errorf("layout defines no folders")
+	}
+	if err := validateFolders(l.Folders, ""); err != nil {
+		return err
+	}
+	for _, role := range requiredRoles {
+		if l.Path(role) == "" {
+			return fmt.Errorf("layout has no folder with role %q", role)
+		}
+	}
+	return nil
+}
+
+func validateFolders(folders []Folder, parent string) error {
+	seen := map[string]bool{}
+	for _, f := range folders {
+		if f.Name == "" || f.Name == "." || f.Name == ".." || strings.ContainsAny(f.Name, `/\`) {
+			return fmt.Errorf("invalid folder name %q in layout", path.Join(parent, f.Name))
+		}
+		if seen[f.Name] {
+			return fmt.Errorf("duplicate folder %q in layout", path.Join(parent, f.Name))
+		}
+		seen[f.Name] = true
+		if err := validateFolders(f.Children, path.Join(parent, f.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Walk calls fn for every folder with its slash-separated path from the root
+func (l *Layout) Walk(fn func(relPath string, f Folder)) {
+	walkFolders(l.Folders, "", fn)
+}
+
+func walkFolders(folders []Folder, parent string, fn func(string, Folder)) {
+	for _, f := range folders {
+		p := path.Join(parent, f.Name)
+		fn(p, f)
+		walkFolders(f.Children, p, fn)
+	}
+}
+
+// Path returns the project-relative path of the first folder with the given
+// role, or an empty string if the layout has none
+func (l *Layout) Path(role string) string {
+	var found string
+	l.Walk(func(p string, f Folder) {
+		if found == "" && f.Role == role {
+			found = filepath.FromSlash(p)
+		}
+	})
+	return found
+}
+
+// Dirs returns the project-relative path of every folder in the layout
+func (l *Layout) Dirs() []string {
+	var dirs []string
+	l.Walk(func(p string, f Folder) {
+		dirs = append(dirs, filepath.FromSlash(p))
+	})
+	return dirs
+}
+
+// SaveTargets returns the folders offered when saving prompt output
+func (l *Layout) SaveTargets() []SaveTarget {
+	var targets []SaveTarget
+	l.Walk(func(p string, f Folder) {
+		if f.SaveLabel != "" {
+			targets = append(targets, SaveTarget{Label: f.SaveLabel, Path: filepath.FromSlash(p)})
+		}
+	})
+	return targets
+}
+
+// TriageTargets maps inbox triage categories to project-relative folders
+func (l *Layout) TriageTargets() map[string]string {
+	targets := map[string]string{}
+	l.Walk(func(p string, f Folder) {
+		if f.Triage != "" {
+			if _, ok := targets[f.Triage]; !ok {
+				targets[f.Triage] = filepath.FromSlash(p)
+			}
+		}
+	})
+	return targets
+}
+
+// Tree renders the layout as an indented tree, showing customerName inside
+// the customers folder when it is set
+func (l *Layout) Tree(customerName string) []string {
+	return treeLines(l.Folders, "", customerName)
+}
+
+func treeLines(folders []Folder, indent, customerName string) []string {
+	var lines []string
+	for i, f := range folders {
+		branch, childIndent := "├── ", indent+"│   "
+		children := f.Children
+		if f.Role == RoleCustomers && customerName != "" {
+			children = append([]Folder{{Name: customerName}}, children...)
+		}
+		if i == len(folders)-1 {
+			branch, childIndent = "└── ", indent+"    "
+		}
+		lines = append(lines, indent+branch+f.Name+"/")
+		lines = append(lines, treeLines(children, childIndent, "")...)
+	}
+	return lines
+}
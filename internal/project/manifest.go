@@ -0,0 +1,109 @@
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile marks the root of a project and records how it was created
+const ManifestFile = ".now-sc.yaml"
+
+// Providers that can be recorded as a project's preferred provider
+const (
+	ProviderClaude     = "claude"
+	ProviderOpenRouter = "openrouter"
+)
+
+// ValidateProjectName rejects names that cannot be used as the project's
+// folder in the current directory
+func ValidateProjectName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("project name is required")
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid project name %q: it must not contain path separators", name)
+	}
+	return nil
+}
+
+// Manifest describes a project. Commands read their defaults from it.
+type Manifest struct {
+	Name          string        `yaml:"name"`
+	Customer      string        `yaml:"customer"`
+	Created       time.Time     `yaml:"created"`
+	LayoutVersion int           `yaml:"layout_version"`
+	Prompts       PromptSources `yaml:"prompts"`
+	// PromptSnapshot is the built-in prompt snapshot the prompts were taken
+	// from because GitHub could not be reached
+	PromptSnapshot string `yaml:"prompt_snapshot,omitempty"`
+	Provider       string `yaml:"provider,omitempty"`
+	Model          string `yaml:"model,omitempty"`
+	Archetype      string `yaml:"archetype,omitempty"`
+	// Settings overrides the user's configuration for this project
+	Settings map[string]string `yaml:"settings,omitempty"`
+}
+
+// FindRoot walks up from start to the nearest directory containing a
+// manifest. Projects created before manifests existed are recognised by
+// their stored layout or prompt templates folder.
+func FindRoot(start string) (string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", start, err)
+	}
+
+	for {
+		for _, marker := range []string{ManifestFile, LayoutFile, "10_PromptTemplates"} {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return dir, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("not inside a now-sc project (no %s found in this or any parent directory); run \"now-sc init\" to create one", ManifestFile)
+		}
+		dir = parent
+	}
+}
+
+// LoadManifest reads a project's manifest. Projects without one get an
+// empty manifest so callers can fall back to built-in defaults.
+func LoadManifest(projectRoot string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(projectRoot, ManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Manifest{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", ManifestFile, err)
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	return &manifest, nil
+}
+
+// Save writes the manifest to the project root
+func (m *Manifest) Save(projectRoot string) error {
+	var buf bytes.Buffer
+	buf.WriteString("# Now-SC project manifest. Commands read their defaults from this file.\n")
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(m); err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(projectRoot, ManifestFile), buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ManifestFile, err)
+	}
+	return nil
+}
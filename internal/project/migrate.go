@@ -0,0 +1,295 @@
+package project
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed layouts/migrations.yaml
+var migrationsData []byte
+
+// Migration moves a project from layout version Version-1 to Version
+type Migration struct {
+	Version     int             `yaml:"version"`
+	Description string          `yaml:"description"`
+	Steps       []MigrationStep `yaml:"steps"`
+}
+
+// MigrationStep is a single change in a migration. Exactly one field is set.
+type MigrationStep struct {
+	Add    string      `yaml:"add,omitempty"`
+	Rename *PathChange `yaml:"rename,omitempty"`
+	Move   *PathChange `yaml:"move,omitempty"`
+}
+
+// PathChange is the source and destination of a rename or move
+type PathChange struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// Change actions
+const (
+	ChangeAdd    = "add"
+	ChangeRename = "rename"
+	ChangeMove   = "move"
+)
+
+// Change is a concrete filesystem change planned for a project
+type Change struct {
+	Action string
+	From   string // Project-relative source, empty for ChangeAdd
+	To     string // Project-relative destination
+	Reason string
+}
+
+// Migrations returns the built-in migrations ordered by version
+func Migrations() ([]Migration, error) {
+	var file struct {
+		Migrations []Migration `yaml:"migrations"`
+	}
+	if err := yaml.Unmarshal(migrationsData, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse migrations: %w", err)
+	}
+
+	sort.Slice(file.Migrations, func(i, j int) bool {
+		return file.Migrations[i].Version < file.Migrations[j].Version
+	})
+	return file.Migrations, nil
+}
+
+// ProjectLayoutVersion returns the layout version a project is at: the
+// manifest's, else the stored layout's, else 0 for projects created before
+// layouts were versioned
+func ProjectLayoutVersion(projectRoot string) (int, error) {
+	if _, err := os.Stat(filepath.Join(projectRoot, ManifestFile)); err == nil {
+		manifest, err := LoadManifest(projectRoot)
+		if err != nil {
+			return 0, err
+		}
+		return manifest.LayoutVersion, nil
+	}
+
+	if _, err := os.Stat(filepath.Join(projectRoot, LayoutFile)); err == nil {
+		layout, err := LoadProjectLayout(projectRoot)
+		if err != nil {
+			return 0, err
+		}
+		return layout.Version, nil
+	}
+
+	return 0, nil
+}
+
+// PlanUpgrade works out the changes that bring a project from layout version
+// from up to target: the steps of every newer migration that still apply,
+// followed by any folders of target that are missing
+func PlanUpgrade(projectRoot string, from int, target *Layout) ([]Change, error) {
+	if from > target.Version {
+		return nil, fmt.Errorf("project layout version %d is newer than this version of now-sc supports (%d); update now-sc", from, target.Version)
+	}
+
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	inTarget := map[string]bool{}
+	for _, dir := range target.Dirs() {
+		inTarget[filepath.ToSlash(dir)] = true
+	}
+
+	// planned tracks paths that will exist once earlier changes have run
+	planned := map[string]bool{}
+	exists := func(p string) bool {
+		if planned[p] {
+			return true
+		}
+		_, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(p)))
+		return err == nil
+	}
+
+	var changes []Change
+	for _, m := range migrations {
+		if m.Version <= from || m.Version > target.Version {
+			continue
+		}
+		reason := fmt.Sprintf("v%d: %s", m.Version, m.Description)
+
+		for _, step := range m.Steps {
+			switch {
+			case step.Add != "":
+				// Folders removed by the project's layout are left out
+				if !inTarget[step.Add] || exists(step.Add) {
+					continue
+				}
+				changes = append(changes, Change{Action: ChangeAdd, To: step.Add, Reason: reason})
+				planned[step.Add] = true
+
+			case step.Rename != nil:
+				if !exists(step.Rename.From) {
+					continue
+				}
+				changes = append(changes, Change{Action: ChangeRename, From: step.Rename.From, To: step.Rename.To, Reason: reason})
+				planned[step.Rename.To] = true
+
+			case step.Move != nil:
+				matches, err := filepath.Glob(filepath.Join(projectRoot, filepath.FromSlash(step.Move.From)))
+				if err != nil {
+					return nil, fmt.Errorf("invalid move pattern %q in migration %d: %w", step.Move.From, m.Version, err)
+				}
+				for _, match := range matches {
+					info, err := os.Stat(match)
+					if err != nil || info.IsDir() {
+						continue
+					}
+					rel, err := filepath.Rel(projectRoot, match)
+					if err != nil {
+						return nil, err
+					}
+					changes = append(changes, Change{Action: ChangeMove, From: filepath.ToSlash(rel), To: step.Move.To, Reason: reason})
+				}
+				planned[step.Move.To] = true
+
+			default:
+				return nil, fmt.Errorf("migration %d has an empty step", m.Version)
+			}
+		}
+	}
+
+	for _, dir := range target.Dirs() {
+		p := filepath.ToSlash(dir)
+		if !exists(p) {
+			changes = append(changes, Change{Action: ChangeAdd, To: p, Reason: "missing from the project"})
+			planned[p] = true
+		}
+	}
+
+	return changes, nil
+}
+
+// UpgradeLayout returns the layout a project has once upgraded from layout
+// version from to target. Folders and settings of the project's current
+// layout, such as those from an archetype, are kept; folders that newer
+// migrations rename are dropped in favour of their new names.
+func UpgradeLayout(current *Layout, from int, target *Layout) (*Layout, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	own := &Layout{Folders: current.Folders}
+	for _, m := range migrations {
+		if m.Version <= from || m.Version > target.Version {
+			continue
+		}
+		for _, step := range m.Steps {
+			if step.Rename != nil {
+				own.Folders = removeFolder(own.Folders, strings.Split(step.Rename.From, "/"))
+			}
+		}
+	}
+
+	merged := target.Merge(own)
+	merged.Version = target.Version
+	if err := merged.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to merge the project layout: %w", err)
+	}
+	return merged, nil
+}
+
+// removeFolder drops the folder at the path given by parts
+func removeFolder(folders []Folder, parts []string) []Folder {
+	result := make([]Folder, 0, len(folders))
+	for _, f := range folders {
+		if f.Name == parts[0] {
+			if len(parts) == 1 {
+				continue
+			}
+			f.Children = removeFolder(f.Children, parts[1:])
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// ApplyChanges performs planned changes in order
+func ApplyChanges(projectRoot string, changes []Change) error {
+	for _, c := range changes {
+		to := filepath.Join(projectRoot, filepath.FromSlash(c.To))
+
+		switch c.Action {
+		case ChangeAdd:
+			if err := os.MkdirAll(to, 0755); err != nil {
+				return fmt.Errorf("failed to create %s: %w", c.To, err)
+			}
+
+		case ChangeRename:
+			if err := mergeDir(filepath.Join(projectRoot, filepath.FromSlash(c.From)), to); err != nil {
+				return fmt.Errorf("failed to rename %s to %s: %w", c.From, c.To, err)
+			}
+
+		case ChangeMove:
+			if err := os.MkdirAll(to, 0755); err != nil {
+				return fmt.Errorf("failed to create %s: %w", c.To, err)
+			}
+			from := filepath.Join(projectRoot, filepath.FromSlash(c.From))
+			if err := os.Rename(from, freePath(filepath.Join(to, filepath.Base(from)))); err != nil {
+				return fmt.Errorf("failed to move %s: %w", c.From, err)
+			}
+
+		default:
+			return fmt.Errorf("unknown change %q", c.Action)
+		}
+	}
+	return nil
+}
+
+// mergeDir renames src to dst, or moves src's entries into dst when it
+// already exists. Files that clash get a numeric suffix.
+func mergeDir(src, dst string) error {
+	if _, err := os.Stat(dst); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		return os.Rename(src, dst)
+	}
+
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		from := filepath.Join(src, entry.Name())
+		to := filepath.Join(dst, entry.Name())
+		if info, err := os.Stat(to); err == nil && info.IsDir() && entry.IsDir() {
+			if err := mergeDir(from, to); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.Rename(from, freePath(to)); err != nil {
+			return err
+		}
+	}
+	return os.Remove(src)
+}
+
+// freePath returns path, or path with a numeric suffix if it already exists
+func freePath(path string) string {
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(path, ext)
+	candidate := path
+	for i := 1; ; i++ {
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s_%d%s", stem, i, ext)
+	}
+}
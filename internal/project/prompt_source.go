@@ -0,0 +1,117 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPromptSource is the prompt pack projects use unless configured otherwise
+var DefaultPromptSource = PromptSource{
+	Repository: "Now-AI-Foundry/Now-SC-Base-Prompts",
+	Ref:        "main",
+	Path:       "Prompts",
+}
+
+// PromptSource records where a project's prompt pack came from. Ref is the
+// branch, tag or commit that was asked for; Commit is what it resolved to.
+type PromptSource struct {
+	Repository string `yaml:"repository"`
+	Ref        string `yaml:"ref"`
+	Path       string `yaml:"path"`
+	Commit     string `yaml:"commit,omitempty"`
+}
+
+// PromptSources lists prompt packs in order of precedence: a prompt in an
+// earlier source replaces the prompt with the same name in a later one
+type PromptSources []PromptSource
+
+// ParsePromptSource parses owner/repo[/path][@ref]. The ref defaults to main.
+func ParsePromptSource(s string) (PromptSource, error) {
+	s = strings.TrimSpace(s)
+	spec, ref, _ := strings.Cut(s, "@")
+	if ref == "" {
+		ref = "main"
+	}
+
+	parts := strings.SplitN(strings.Trim(spec, "/"), "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return PromptSource{}, fmt.Errorf("invalid prompt source %q: expected owner/repo[/path][@ref]", s)
+	}
+
+	source := PromptSource{Repository: parts[0] + "/" + parts[1], Ref: ref}
+	if len(parts) == 3 {
+		source.Path = strings.Trim(parts[2], "/")
+	}
+	return source, nil
+}
+
+// ParsePromptSources parses a comma-separated list of prompt sources
+func ParsePromptSources(s string) (PromptSources, error) {
+	var sources PromptSources
+	for _, item := range strings.Split(s, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		source, err := ParsePromptSource(item)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no prompt source given")
+	}
+	return sources, nil
+}
+
+// String formats the source as owner/repo[/path]@ref
+func (s PromptSource) String() string {
+	spec := s.Repository
+	if s.Path != "" {
+		spec += "/" + s.Path
+	}
+	return spec + "@" + s.Ref
+}
+
+// FetchRef is the ref to download: the recorded commit when there is one,
+// so a project keeps getting the prompts it was pinned to
+func (s PromptSource) FetchRef() string {
+	if s.Commit != "" {
+		return s.Commit
+	}
+	return s.Ref
+}
+
+// String formats the sources as a comma-separated list
+func (s PromptSources) String() string {
+	items := make([]string, len(s))
+	for i, source := range s {
+		items[i] = source.String()
+	}
+	return strings.Join(items, ",")
+}
+
+// UnmarshalYAML accepts a list of sources or, as manifests written before
+// multiple sources were supported have, a single one
+func (s *PromptSources) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.MappingNode {
+		var source PromptSource
+		if err := value.Decode(&source); err != nil {
+			return err
+		}
+		*s = nil
+		if source.Repository != "" {
+			*s = PromptSources{source}
+		}
+		return nil
+	}
+
+	var sources []PromptSource
+	if err := value.Decode(&sources); err != nil {
+		return err
+	}
+	*s = sources
+	return nil
+}
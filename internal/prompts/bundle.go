@@ -0,0 +1,159 @@
+package prompts
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/github"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+// bundleIndex lists the packs in a bundle
+const bundleIndex = "now-sc-bundle.json"
+
+// bundleEntry is one prompt pack in a bundle
+type bundleEntry struct {
+	Source  project.PromptSource `json:"source"`
+	Archive string               `json:"archive"`
+}
+
+// Export writes the packs of sources into a bundle that Import can load on
+// a machine without access to GitHub. It returns the exported sources with
+// their commits and any sources taken from the cache without revalidation.
+func (c *Cache) Export(sources project.PromptSources, bundlePath string) (*FetchResult, error) {
+	result := &FetchResult{}
+	var packs []*Pack
+	for _, source := range sources {
+		pack, err := c.Pack(source)
+		if err != nil {
+			return nil, err
+		}
+		if pack.Stale != nil {
+			result.Stale = append(result.Stale, fmt.Sprintf("%s: using cached commit %.7s (%v)", source, pack.Source.Commit, pack.Stale))
+		}
+		packs = append(packs, pack)
+		result.Sources = append(result.Sources, pack.Source)
+	}
+
+	file, err := os.OpenFile(bundlePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bundle: %w", err)
+	}
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	writeErr := func() error {
+		var index []bundleEntry
+		for _, pack := range packs {
+			name := "archives/" + strings.ReplaceAll(pack.Source.Repository, "/", "_") + "-" + pack.Source.Commit + ".tar.gz"
+			index = append(index, bundleEntry{Source: pack.Source, Archive: name})
+			if err := writeTarFile(tw, name, pack.Archive); err != nil {
+				return err
+			}
+		}
+		data, err := json.MarshalIndent(index, "", "  ")
+		if err != nil {
+			return err
+		}
+		return writeTarFile(tw, bundleIndex, data)
+	}()
+
+	tarErr := tw.Close()
+	gzErr := gz.Close()
+	fileErr := file.Close()
+	for _, err := range []error{writeErr, tarErr, gzErr, fileErr} {
+		if err != nil {
+			os.Remove(bundlePath)
+			return nil, fmt.Errorf("failed to write bundle: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// Import adds the packs in a bundle to the cache after checking each one,
+// so that prompts can be fetched from them offline. It returns the sources
+// the bundle holds.
+func (c *Cache) Import(bundlePath string) (project.PromptSources, error) {
+	file, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer file.Close()
+
+	index, archives, err := readBundle(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a prompt bundle: %w", bundlePath, err)
+	}
+
+	var sources project.PromptSources
+	for _, entry := range index {
+		source := entry.Source
+		data, ok := archives[entry.Archive]
+		if !ok || path.Clean(entry.Archive) != entry.Archive {
+			return nil, fmt.Errorf("bundle is missing the archive of %s", source)
+		}
+		if _, err := github.ExtractArchive(data, source.Commit, "", nil); err != nil {
+			return nil, fmt.Errorf("invalid archive of %s in bundle: %w", source, err)
+		}
+		if err := c.StoreArchive(source.Repository, source.Commit, data); err != nil {
+			return nil, err
+		}
+		if source.Ref != "" && source.Ref != source.Commit {
+			if err := c.RecordRef(source.Repository, source.Ref, source.Commit); err != nil {
+				return nil, err
+			}
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// readBundle reads the index of a bundle and the archives it holds
+func readBundle(r io.Reader) ([]bundleEntry, map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	tr := tar.NewReader(gz)
+
+	archives := map[string][]byte{}
+	var index []bundleEntry
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, err
+		}
+		if header.Name == bundleIndex {
+			if err := json.Unmarshal(data, &index); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse %s: %w", bundleIndex, err)
+			}
+			continue
+		}
+		archives[header.Name] = data
+	}
+	if index == nil {
+		return nil, nil, fmt.Errorf("%s is missing", bundleIndex)
+	}
+	return index, archives, nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
@@ -0,0 +1,182 @@
+package prompts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/github"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+// Cache keeps downloaded prompt pack archives in the user cache directory so
+// projects can still be created when GitHub cannot be reached. Archives are
+// stored per commit and never change; refs remember the commit they last
+// resolved to along with an ETag to revalidate it.
+type Cache struct {
+	Dir    string
+	GitHub *github.Client // Where packs are downloaded from
+}
+
+// cachedRef is what a ref resolved to when it was last checked
+type cachedRef struct {
+	Repository string    `json:"repository"`
+	Ref        string    `json:"ref"`
+	Commit     string    `json:"commit"`
+	ETag       string    `json:"etag,omitempty"`
+	Checked    time.Time `json:"checked"`
+}
+
+// OpenCache returns the cache in the user cache directory, filled from gh
+func OpenCache(gh *github.Client) (*Cache, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate user cache directory: %w", err)
+	}
+	return &Cache{Dir: filepath.Join(cacheDir, "now-sc", "packs"), GitHub: gh}, nil
+}
+
+// Pack is the archive of a prompt source at a commit
+type Pack struct {
+	Source  project.PromptSource // With Commit set
+	Archive []byte
+	Stale   error // Why the ref could not be revalidated, when a cached copy was used
+	// Snapshot is set when the pack is the one built into the binary
+	Snapshot bool
+}
+
+// Pack returns the archive of source. A pinned commit is used as is.
+// Otherwise the ref is revalidated with GitHub and, when that fails, the last
+// commit it resolved to is used if its archive is cached. The snapshot built
+// into the binary is the last resort.
+func (c *Cache) Pack(source project.PromptSource) (*Pack, error) {
+	var stale error
+	commit := source.Commit
+	if commit == "" {
+		cached, _ := c.loadRef(source.Repository, source.Ref)
+		etag := ""
+		if cached != nil && c.hasArchive(source.Repository, cached.Commit) {
+			etag = cached.ETag
+		}
+
+		resolved, resolveErr := c.GitHub.ResolveCommit(source.Repository, source.Ref, etag)
+		switch {
+		case resolveErr != nil:
+			if cached == nil || !c.hasArchive(source.Repository, cached.Commit) {
+				if pack := snapshotPack(source); pack != nil {
+					pack.Stale = resolveErr
+					return pack, nil
+				}
+				return nil, fmt.Errorf("failed to resolve %s and no cached copy is available: %w", source, resolveErr)
+			}
+			commit, stale = cached.Commit, resolveErr
+		case resolved.NotModified:
+			commit = cached.Commit
+		default:
+			commit = resolved.SHA
+			if err := c.saveRef(&cachedRef{Repository: source.Repository, Ref: source.Ref, Commit: commit, ETag: resolved.ETag}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	source.Commit = commit
+	pack := &Pack{Source: source, Stale: stale}
+
+	if data, err := os.ReadFile(c.archivePath(source.Repository, commit)); err == nil {
+		pack.Archive = data
+		return pack, nil
+	}
+
+	data, err := c.GitHub.DownloadTarball(source.Repository, commit)
+	if err != nil {
+		if pack := snapshotPack(source); pack != nil {
+			pack.Stale = err
+			return pack, nil
+		}
+		return nil, err
+	}
+	// Check the download before it is cached
+	if _, err := github.ExtractArchive(data, commit, "", nil); err != nil {
+		return nil, fmt.Errorf("invalid archive of %s: %w", source.Repository, err)
+	}
+	if err := c.StoreArchive(source.Repository, commit, data); err != nil {
+		return nil, err
+	}
+	pack.Archive = data
+	return pack, nil
+}
+
+// StoreArchive adds the archive of repo at commit to the cache
+func (c *Cache) StoreArchive(repo, commit string, data []byte) error {
+	return writeAtomic(c.archivePath(repo, commit), data)
+}
+
+// RecordRef remembers that ref resolved to commit, as when importing a bundle
+func (c *Cache) RecordRef(repo, ref, commit string) error {
+	if cached, _ := c.loadRef(repo, ref); cached != nil && cached.Commit == commit {
+		return nil
+	}
+	return c.saveRef(&cachedRef{Repository: repo, Ref: ref, Commit: commit})
+}
+
+func (c *Cache) archivePath(repo, commit string) string {
+	return filepath.Join(c.Dir, "archives", strings.ReplaceAll(repo, "/", "_")+"-"+commit+".tar.gz")
+}
+
+func (c *Cache) hasArchive(repo, commit string) bool {
+	_, err := os.Stat(c.archivePath(repo, commit))
+	return err == nil
+}
+
+// refPath keys refs by a hash, since refs may contain slashes. Refs of
+// GitHub Enterprise repositories are kept apart from github.com ones.
+func (c *Cache) refPath(repo, ref string) string {
+	key := repo + "@" + ref
+	if c.GitHub.APIURL != github.DefaultAPIURL {
+		key = c.GitHub.APIURL + "/" + key
+	}
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, "refs", hex.EncodeToString(sum[:8])+".json")
+}
+
+func (c *Cache) loadRef(repo, ref string) (*cachedRef, error) {
+	data, err := os.ReadFile(c.refPath(repo, ref))
+	if err != nil {
+		return nil, err
+	}
+	var cached cachedRef
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("failed to parse cached ref: %w", err)
+	}
+	return &cached, nil
+}
+
+func (c *Cache) saveRef(cached *cachedRef) error {
+	cached.Checked = time.Now().UTC().Truncate(time.Second)
+	data, err := json.MarshalIndent(cached, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cached ref: %w", err)
+	}
+	return writeAtomic(c.refPath(cached.Repository, cached.Ref), data)
+}
+
+// writeAtomic writes through a temporary file so readers never see a partial file
+func writeAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
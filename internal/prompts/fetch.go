@@ -0,0 +1,95 @@
+package prompts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/github"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+// FetchResult describes the prompt packs a fetch used
+type FetchResult struct {
+	Sources project.PromptSources // With the commit each was fetched at
+	Stale   []string              // Sources taken from the cache without revalidation, and why
+	// Snapshot is the version of the built-in snapshot, when any prompts came from it
+	Snapshot string
+}
+
+// Fetch writes the prompt packs of sources into dir, downloading them
+// through the cache. Later sources are written first so that earlier ones,
+// which take precedence, overwrite the prompts they share.
+func Fetch(gh *github.Client, sources project.PromptSources, dir string) (*FetchResult, error) {
+	packs, result, err := loadPacks(gh, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(packs) - 1; i >= 0; i-- {
+		source := packs[i].Source
+		files, err := github.ExtractArchive(packs[i].Archive, source.Commit, source.Path, func(path string) bool {
+			return strings.HasSuffix(path, ".md")
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive of %s: %w", sources[i], err)
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("%s has no prompts at %.7s", sources[i], source.Commit)
+		}
+		if err := writeFiles(dir, files); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// loadPacks gets the pack of each source through the cache
+func loadPacks(gh *github.Client, sources project.PromptSources) ([]*Pack, *FetchResult, error) {
+	cache, err := OpenCache(gh)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	packs := make([]*Pack, len(sources))
+	result := &FetchResult{Sources: make(project.PromptSources, len(sources))}
+	for i, source := range sources {
+		pack, err := cache.Pack(source)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case pack.Snapshot:
+			result.Snapshot = SnapshotVersion()
+			result.Stale = append(result.Stale, fmt.Sprintf("%s: using the prompts built into now-sc at %.7s (%v)", source, pack.Source.Commit, pack.Stale))
+		case pack.Stale != nil:
+			result.Stale = append(result.Stale, fmt.Sprintf("%s: using cached commit %.7s (%v)", source, pack.Source.Commit, pack.Stale))
+		}
+		packs[i] = pack
+		result.Sources[i] = pack.Source
+	}
+	return packs, result, nil
+}
+
+// writeFiles writes extracted files below dir
+func writeFiles(dir string, files []github.ArchiveFile) error {
+	for _, file := range files {
+		if err := writeFile(dir, file.Path, file.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile writes content to path below dir, creating its folder
+func writeFile(dir, path string, content []byte) error {
+	target := filepath.Join(dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create folder for %s: %w", path, err)
+	}
+	if err := os.WriteFile(target, content, 0644); err != nil {
+		return fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package prompts
+
+import (
+	"strings"
+)
+
+// Conflict markers written around the two sides of a conflicting change
+const (
+	markerLocal    = "<<<<<<< local\n"
+	markerBase     = "||||||| base\n"
+	markerSplit    = "=======\n"
+	markerUpstream = ">>>>>>> upstream\n"
+)
+
+// Merge3 merges the changes from base to local and from base to upstream,
+// line by line. Where both sides changed the same lines differently the
+// result holds both versions between conflict markers and conflict is true.
+func Merge3(base, local, upstream string) (merged string, conflict bool) {
+	baseLines := splitLines(base)
+	localLines := splitLines(local)
+	upstreamLines := splitLines(upstream)
+
+	toLocal := matchLines(baseLines, localLines)
+	toUpstream := matchLines(baseLines, upstreamLines)
+
+	var out strings.Builder
+	i, a, b := 0, 0, 0
+	for {
+		// Find the next base line both sides kept
+		k := i
+		for k < len(baseLines) && (toLocal[k] < 0 || toUpstream[k] < 0) {
+			k++
+		}
+
+		localEnd, upstreamEnd := len(localLines), len(upstreamLines)
+		if k < len(baseLines) {
+			localEnd, upstreamEnd = toLocal[k], toUpstream[k]
+		}
+		if mergeChunk(&out, baseLines[i:k], localLines[a:localEnd], upstreamLines[b:upstreamEnd]) {
+			conflict = true
+		}
+
+		if k == len(baseLines) {
+			break
+		}
+		out.WriteString(baseLines[k] + "\n")
+		i, a, b = k+1, localEnd+1, upstreamEnd+1
+	}
+	return out.String(), conflict
+}
+
+// mergeChunk writes the merge of one region between stable lines and
+// reports whether it conflicted
+func mergeChunk(out *strings.Builder, base, local, upstream []string) bool {
+	switch {
+	case equalLines(local, base):
+		writeLines(out, upstream)
+	case equalLines(upstream, base), equalLines(local, upstream):
+		writeLines(out, local)
+	default:
+		out.WriteString(markerLocal)
+		writeLines(out, local)
+		out.WriteString(markerBase)
+		writeLines(out, base)
+		out.WriteString(markerSplit)
+		writeLines(out, upstream)
+		out.WriteString(markerUpstream)
+		return true
+	}
+	return false
+}
+
+// matchLines pairs lines of a with lines of b along a longest common
+// subsequence. The result maps each index of a to its index in b, or -1.
+func matchLines(a, b []string) []int {
+	n, m := len(a), len(b)
+	lengths := make([][]int, n+1)
+	for i := range lengths {
+		lengths[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	match := make([]int, n)
+	for i := range match {
+		match[i] = -1
+	}
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case a[i] == b[j]:
+			match[i] = j
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match
+}
+
+// splitLines splits s into lines without their line endings
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeLines(out *strings.Builder, lines []string) {
+	for _, line := range lines {
+		out.WriteString(line + "\n")
+	}
+}
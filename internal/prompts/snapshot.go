@@ -0,0 +1,70 @@
+package prompts
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+// snapshotFile is the bundle "make snapshot" writes into the binary
+const snapshotFile = "snapshot/base-prompts.tar.gz"
+
+//go:embed snapshot
+var snapshotFS embed.FS
+
+var (
+	snapshotOnce     sync.Once
+	snapshotIndex    []bundleEntry
+	snapshotArchives map[string][]byte
+)
+
+// loadSnapshot reads the built-in bundle once. A binary built without one
+// has an empty snapshot.
+func loadSnapshot() {
+	snapshotOnce.Do(func() {
+		data, err := snapshotFS.ReadFile(snapshotFile)
+		if err != nil {
+			return
+		}
+		snapshotIndex, snapshotArchives, _ = readBundle(bytes.NewReader(data))
+	})
+}
+
+// SnapshotVersion describes the prompt packs built into the binary as
+// repository@commit, or returns "" when it has none
+func SnapshotVersion() string {
+	loadSnapshot()
+	versions := make([]string, len(snapshotIndex))
+	for i, entry := range snapshotIndex {
+		versions[i] = fmt.Sprintf("%s@%.7s", entry.Source.Repository, entry.Source.Commit)
+	}
+	return strings.Join(versions, ", ")
+}
+
+// snapshotPack returns the built-in pack of source: the pinned commit if it
+// has one, otherwise whatever its ref pointed to when the snapshot was made
+func snapshotPack(source project.PromptSource) *Pack {
+	loadSnapshot()
+	for _, entry := range snapshotIndex {
+		if entry.Source.Repository != source.Repository {
+			continue
+		}
+		if source.Commit != "" && entry.Source.Commit != source.Commit {
+			continue
+		}
+		if source.Commit == "" && entry.Source.Ref != source.Ref {
+			continue
+		}
+		data, ok := snapshotArchives[entry.Archive]
+		if !ok {
+			continue
+		}
+		source.Commit = entry.Source.Commit
+		return &Pack{Source: source, Archive: data, Snapshot: true}
+	}
+	return nil
+}
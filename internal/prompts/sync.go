@@ -0,0 +1,188 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+// BaseDir keeps the upstream version of each prompt as it was last fetched,
+// relative to the project root. Sync merges against it.
+const BaseDir = ".now-sc/prompts-base"
+
+// Status is what a sync does to a prompt
+type Status string
+
+const (
+	StatusNew             Status = "new"              // Added upstream
+	StatusUpdated         Status = "updated"          // Changed upstream, not locally
+	StatusMerged          Status = "merged"           // Changed on both sides without overlapping
+	StatusConflict        Status = "conflict"         // Changed on both sides in the same place
+	StatusLocallyModified Status = "locally modified" // Changed locally only, kept
+	StatusRemoved         Status = "removed"          // Removed upstream, not changed locally
+	StatusDeletedLocally  Status = "deleted locally"  // Removed locally, stays removed
+	StatusUnchanged       Status = "unchanged"
+)
+
+// Change is the outcome of syncing one prompt
+type Change struct {
+	Path    string // Relative to the prompts folder, with forward slashes
+	Status  Status
+	Content []byte // What the local file becomes; nil when it is left alone or removed
+}
+
+// writes reports whether applying the change touches the local file
+func (c Change) writes() bool {
+	return c.Content != nil || c.Status == StatusRemoved
+}
+
+// PlanSync compares the local prompts, their recorded base and a fresh
+// upstream copy and works out what syncing does to each file
+func PlanSync(projectRoot, promptsDir, upstreamDir string) ([]Change, error) {
+	baseDir := filepath.Join(projectRoot, BaseDir)
+	paths := map[string]bool{}
+	for _, dir := range []string{baseDir, upstreamDir} {
+		files, err := listIfExists(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range files {
+			paths[path] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	var changes []Change
+	for _, path := range sorted {
+		base, hasBase, err := readIfExists(filepath.Join(baseDir, path))
+		if err != nil {
+			return nil, err
+		}
+		local, hasLocal, err := readIfExists(filepath.Join(promptsDir, path))
+		if err != nil {
+			return nil, err
+		}
+		upstream, hasUpstream, err := readIfExists(filepath.Join(upstreamDir, path))
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, planFile(path, base, hasBase, local, hasLocal, upstream, hasUpstream))
+	}
+	return changes, nil
+}
+
+// planFile decides what happens to one prompt
+func planFile(path string, base []byte, hasBase bool, local []byte, hasLocal bool, upstream []byte, hasUpstream bool) Change {
+	change := Change{Path: path, Status: StatusUnchanged}
+	localChanged := hasLocal && (!hasBase || !bytes.Equal(local, base))
+
+	switch {
+	case !hasUpstream:
+		// Removed upstream
+		switch {
+		case !hasLocal:
+		case localChanged:
+			change.Status = StatusLocallyModified
+		default:
+			change.Status = StatusRemoved
+		}
+	case !hasLocal:
+		if hasBase {
+			change.Status = StatusDeletedLocally
+		} else {
+			change.Status = StatusNew
+			change.Content = upstream
+		}
+	case bytes.Equal(local, upstream):
+	case hasBase && bytes.Equal(upstream, base):
+		change.Status = StatusLocallyModified
+	case !localChanged:
+		change.Status = StatusUpdated
+		change.Content = upstream
+	default:
+		merged, conflict := Merge3(string(base), string(local), string(upstream))
+		change.Status = StatusMerged
+		if conflict {
+			change.Status = StatusConflict
+		}
+		change.Content = []byte(merged)
+	}
+	return change
+}
+
+// ApplySync writes the planned changes and records upstream as the new base
+func ApplySync(projectRoot, promptsDir, upstreamDir string, changes []Change) error {
+	for _, change := range changes {
+		if !change.writes() {
+			continue
+		}
+		target := filepath.Join(promptsDir, filepath.FromSlash(change.Path))
+		if change.Status == StatusRemoved {
+			if err := os.Remove(target); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", change.Path, err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create folder for %s: %w", change.Path, err)
+		}
+		if err := os.WriteFile(target, change.Content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", change.Path, err)
+		}
+	}
+	return RecordBase(projectRoot, upstreamDir)
+}
+
+// RecordBase replaces the recorded base with the prompts in dir
+func RecordBase(projectRoot, dir string) error {
+	baseDir := filepath.Join(projectRoot, BaseDir)
+	if err := os.RemoveAll(baseDir); err != nil {
+		return fmt.Errorf("failed to clear %s: %w", BaseDir, err)
+	}
+
+	files, err := project.ListFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, path := range files {
+		content, err := os.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		target := filepath.Join(baseDir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return fmt.Errorf("failed to record base of %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func listIfExists(dir string) ([]string, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return project.ListFiles(dir)
+}
+
+func readIfExists(path string) ([]byte, bool, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return content, true, nil
+}
@@ -0,0 +1,136 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/github"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+// TemplatesPath is the folder of a prompt pack repository holding communication templates
+const TemplatesPath = "Templates"
+
+// Template is a communication template found in a prompt pack
+type Template struct {
+	Path     string // Relative to the templates folder, with forward slashes
+	Category string // Subfolder the template is in, empty at the top level
+	Source   project.PromptSource
+	Content  []byte
+}
+
+// Name is the template's file name without its extension
+func (t Template) Name() string {
+	base := path.Base(t.Path)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// Matches reports whether name refers to the template, by path, by
+// category/name or by name alone
+func (t Template) Matches(name string) bool {
+	name = strings.ToLower(strings.Trim(filepath.ToSlash(name), "/"))
+	withoutExt := strings.TrimSuffix(t.Path, path.Ext(t.Path))
+	return name == strings.ToLower(t.Path) || name == strings.ToLower(withoutExt) || name == strings.ToLower(t.Name())
+}
+
+// TemplateSet is what DiscoverTemplates found
+type TemplateSet struct {
+	*FetchResult
+	Templates []Template // Top-level templates first, then by category
+}
+
+// DiscoverTemplates lists every file in the templates folder of each
+// source. A template in an earlier source hides the one with the same path
+// in a later source.
+func DiscoverTemplates(gh *github.Client, sources project.PromptSources) (*TemplateSet, error) {
+	packs, result, err := loadPacks(gh, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &TemplateSet{FetchResult: result}
+	seen := map[string]bool{}
+	for _, pack := range packs {
+		files, err := github.ExtractArchive(pack.Archive, pack.Source.Commit, TemplatesPath, func(path string) bool {
+			return !isHidden(path)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive of %s: %w", pack.Source, err)
+		}
+		for _, file := range files {
+			if seen[file.Path] {
+				continue
+			}
+			seen[file.Path] = true
+			category := path.Dir(file.Path)
+			if category == "." {
+				category = ""
+			}
+			set.Templates = append(set.Templates, Template{Path: file.Path, Category: category, Source: pack.Source, Content: file.Content})
+		}
+	}
+	sort.Slice(set.Templates, func(i, j int) bool {
+		a, b := set.Templates[i], set.Templates[j]
+		if (a.Category == "") != (b.Category == "") {
+			return a.Category == ""
+		}
+		return a.Path < b.Path
+	})
+	return set, nil
+}
+
+// PullStatus is what pulling did to one template
+type PullStatus string
+
+const (
+	PullWritten   PullStatus = "written"
+	PullUnchanged PullStatus = "unchanged"
+	PullSkipped   PullStatus = "skipped" // Changed locally, kept
+	PullFailed    PullStatus = "failed"
+)
+
+// PullResult is the outcome of pulling one template
+type PullResult struct {
+	Template Template
+	Status   PullStatus
+	Err      error
+}
+
+// PullTemplates writes templates into dir and reports each one. Templates
+// that were changed locally are kept unless overwrite is set.
+func PullTemplates(templates []Template, dir string, overwrite bool) []PullResult {
+	results := make([]PullResult, len(templates))
+	for i, template := range templates {
+		results[i] = PullResult{Template: template, Status: PullWritten}
+		local, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(template.Path)))
+		switch {
+		case err == nil && bytes.Equal(local, template.Content):
+			results[i].Status = PullUnchanged
+			continue
+		case err == nil && !overwrite:
+			results[i].Status = PullSkipped
+			continue
+		case err != nil && !os.IsNotExist(err):
+			results[i].Status, results[i].Err = PullFailed, fmt.Errorf("failed to read %s: %w", template.Path, err)
+			continue
+		}
+		if err := writeFile(dir, template.Path, template.Content); err != nil {
+			results[i].Status, results[i].Err = PullFailed, err
+		}
+	}
+	return results
+}
+
+func isHidden(path string) bool {
+	for _, part := range strings.Split(path, "/") {
+		if strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
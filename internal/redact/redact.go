@@ -0,0 +1,219 @@
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Kinds of sensitive data the built-in detectors recognise
+const (
+	KindSecret = "SECRET"
+	KindEmail  = "EMAIL"
+	KindIP     = "IP"
+	KindPhone  = "PHONE"
+	KindCustom = "CUSTOM"
+	KindTerm   = "TERM"
+)
+
+// Config adds customer-specific detectors to the built-in ones
+type Config struct {
+	Patterns []string `json:"patterns"` // Extra regular expressions to redact
+	Words    []string `json:"words"`    // Names and terms redacted as whole words, case-insensitive
+	Disable  []string `json:"disable"`  // Built-in kinds to turn off, e.g. "IP"
+}
+
+// Redaction is a single value replaced by a placeholder
+type Redaction struct {
+	Placeholder string
+	Original    string
+	Kind        string
+}
+
+type detector struct {
+	kind    string
+	pattern *regexp.Regexp
+	group   int               // Submatch to redact, 0 for the whole match
+	valid   func(string) bool // Optional extra check on the match
+}
+
+// builtins run in order: secrets first so key=value pairs are caught before
+// their values are mistaken for anything else
+var builtins = []detector{
+	{kind: KindSecret, pattern: regexp.MustCompile(`\b(?:sk-(?:or-|ant-|proj-)?[A-Za-z0-9_-]{20,}|gh[pousr]_[A-Za-z0-9]{30,}|github_pat_[A-Za-z0-9_]{40,}|AKIA[0-9A-Z]{16}|xox[abprs]-[A-Za-z0-9-]{10,}|AIza[0-9A-Za-z_-]{35})\b`)},
+	{kind: KindSecret, pattern: regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9._~+/=-]{16,})`), group: 1},
+	{kind: KindSecret, pattern: regexp.MustCompile(`(?i)\b(?:api[_-]?key|secret|password|passwd|pwd|token|client[_-]?secret)\b\s*[:=]\s*["']?([^\s"',;]{6,})`), group: 1},
+	{kind: KindEmail, pattern: regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`)},
+	{kind: KindIP, pattern: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`)},
+	{kind: KindIP, pattern: regexp.MustCompile(`\b(?:[0-9A-Fa-f]{1,4}:){7}[0-9A-Fa-f]{1,4}\b`)},
+	{kind: KindPhone, pattern: regexp.MustCompile(`\+?\(?\d[\d \t().-]{7,}\d`), valid: isPhoneNumber},
+}
+
+// Redactor replaces sensitive values with stable placeholders and can
+// restore them in text that echoes those placeholders back
+type Redactor struct {
+	detectors  []detector
+	byOriginal map[string]string
+	redactions []Redaction
+	counts     map[string]int
+}
+
+// New creates a redactor with the built-in detectors plus those in cfg
+func New(cfg Config) (*Redactor, error) {
+	disabled := map[string]bool{}
+	for _, kind := range cfg.Disable {
+		disabled[strings.ToUpper(kind)] = true
+	}
+
+	r := &Redactor{
+		byOriginal: map[string]string{},
+		counts:     map[string]int{},
+	}
+	for _, d := range builtins {
+		if !disabled[d.kind] {
+			r.detectors = append(r.detectors, d)
+		}
+	}
+
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.detectors = append(r.detectors, detector{kind: KindCustom, pattern: re})
+	}
+
+	// Longest words first so "Acme Corp" wins over "Acme"
+	words := append([]string(nil), cfg.Words...)
+	sort.Slice(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w == "" {
+			continue
+		}
+		re := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(w) + `\b`)
+		r.detectors = append(r.detectors, detector{kind: KindTerm, pattern: re})
+	}
+
+	return r, nil
+}
+
+// Redact replaces every detected value in text with a placeholder such as
+// [EMAIL_1]. The same value always maps to the same placeholder.
+func (r *Redactor) Redact(text string) string {
+	for _, d := range r.detectors {
+		text = r.apply(d, text)
+	}
+	return text
+}
+
+// Restore replaces placeholders in text with the values they stand for
+func (r *Redactor) Restore(text string) string {
+	if len(r.redactions) == 0 {
+		return text
+	}
+	pairs := make([]string, 0, len(r.redactions)*2)
+	for _, red := range r.redactions {
+		pairs = append(pairs, red.Placeholder, red.Original)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// Redactions returns every value replaced so far, in order of discovery
+func (r *Redactor) Redactions() []Redaction {
+	return r.redactions
+}
+
+func (r *Redactor) apply(d detector, text string) string {
+	matches := d.pattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var builder strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[2*d.group], m[2*d.group+1]
+		if start < 0 || start < last {
+			continue
+		}
+		value := text[start:end]
+		if isPlaceholder(value) || (d.valid != nil && !d.valid(value)) {
+			continue
+		}
+		builder.WriteString(text[last:start])
+		builder.WriteString(r.placeholder(d.kind, value))
+		last = end
+	}
+	builder.WriteString(text[last:])
+	return builder.String()
+}
+
+func (r *Redactor) placeholder(kind, value string) string {
+	if p, ok := r.byOriginal[value]; ok {
+		return p
+	}
+	r.counts[kind]++
+	p := fmt.Sprintf("[%s_%d]", kind, r.counts[kind])
+	r.byOriginal[value] = p
+	r.redactions = append(r.redactions, Redaction{Placeholder: p, Original: value, Kind: kind})
+	return p
+}
+
+var placeholderPattern = regexp.MustCompile(`^\[[A-Z]+_\d+\]$`)
+
+func isPlaceholder(s string) bool {
+	return placeholderPattern.MatchString(s)
+}
+
+// isPhoneNumber accepts 9 to 15 digits and rejects ISO dates
+func isPhoneNumber(s string) bool {
+	digits := 0
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			digits++
+		}
+	}
+	if digits < 9 || digits > 15 {
+		return false
+	}
+	return !isoDatePattern.MatchString(s)
+}
+
+var isoDatePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+
+// LoadConfig reads and merges the redaction config files that exist among paths
+func LoadConfig(paths ...string) (Config, error) {
+	var merged Config
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return merged, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		var cfg Config
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return merged, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		merged.Patterns = append(merged.Patterns, cfg.Patterns...)
+		merged.Words = append(merged.Words, cfg.Words...)
+		merged.Disable = append(merged.Disable, cfg.Disable...)
+	}
+	return merged, nil
+}
+
+// Mask shortens a value for display so previews do not reprint secrets
+func Mask(r Redaction) string {
+	if r.Kind != KindSecret {
+		return r.Original
+	}
+	if len(r.Original) <= 8 {
+		return strings.Repeat("*", len(r.Original))
+	}
+	return r.Original[:4] + strings.Repeat("*", len(r.Original)-8) + r.Original[len(r.Original)-4:]
+}